@@ -1,7 +1,6 @@
 package main
 
 import (
-	_ "embed"
 	"flag"
 	"fmt"
 	"math"
@@ -12,21 +11,19 @@ import (
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-//go:embed input.txt
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
 	var part int
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
+	readInput := util.InputFlags(flag.CommandLine)
 	flag.Parse()
+	input = readInput()
 	fmt.Println("Running part", part)
 
 	if part == 1 {
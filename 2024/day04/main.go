@@ -1,7 +1,6 @@
 package main
 
 import (
-	_ "embed"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -10,7 +9,6 @@ import (
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-//go:embed input.txt
 var input string
 
 const search1 = "XMAS"
@@ -19,10 +17,7 @@ const search2Rev = "SAM" // To simplify, just reverse the search string to do a
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
@@ -30,7 +25,9 @@ func main() {
 	var debug bool
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
 	flag.BoolVar(&debug, "debug", false, "debug mode")
+	readInput := util.InputFlags(flag.CommandLine)
 	flag.Parse()
+	input = readInput()
 	fmt.Println("Running part", part)
 	if debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
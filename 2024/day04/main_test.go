@@ -3,6 +3,8 @@ package main
 import (
 	"log/slog"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `MMMSXXMASM
@@ -36,6 +38,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -63,9 +68,16 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
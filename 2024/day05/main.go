@@ -1,7 +1,6 @@
 package main
 
 import (
-	_ "embed"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -18,15 +17,11 @@ type Rule struct {
 	after  []int
 }
 
-//go:embed input.txt
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
@@ -34,7 +29,9 @@ func main() {
 	var debug bool
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
 	flag.BoolVar(&debug, "debug", false, "debug mode")
+	readInput := util.InputFlags(flag.CommandLine)
 	flag.Parse()
+	input = readInput()
 	fmt.Println("Running part", part)
 	if debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
@@ -1,24 +1,21 @@
 package main
 
 import (
-	_ "embed"
 	"flag"
 	"fmt"
 	"log/slog"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-//go:embed input.txt
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
@@ -26,7 +23,9 @@ func main() {
 	var debug bool
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
 	flag.BoolVar(&debug, "debug", false, "debug mode")
+	readInput := util.InputFlags(flag.CommandLine)
 	flag.Parse()
+	input = readInput()
 	fmt.Println("Running part", part)
 	if debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
@@ -59,11 +58,45 @@ type Dir struct {
 	x, y int
 }
 
+// index returns the position of the direction clockwise, starting at UP
+func (d Dir) index() int {
+	switch d {
+	case UP:
+		return 0
+	case RIGHT:
+		return 1
+	case DOWN:
+		return 2
+	case LEFT:
+		return 3
+	}
+	return -1
+}
+
+// mask returns the bit of the direction in Position.dirs
+func (d Dir) mask() uint8 {
+	return 1 << d.index()
+}
+
+func (d Dir) String() string {
+	for char, dir := range dirTransform {
+		if dir == d {
+			return string(char)
+		}
+	}
+	return "?"
+}
+
+func (d Dir) turnRight() Dir {
+	return Dir{-d.y, d.x}
+}
+
 type Position struct {
 	x, y     int
 	obstacle bool
 	visited  bool
-	dir      Dir
+	dir      Dir   // Direction the guard first entered the position with
+	dirs     uint8 // Every direction the guard faced while on the position, see Dir.mask
 }
 
 type Guard struct {
@@ -82,39 +115,231 @@ func (g *Guard) moveForward() {
 	g.y += g.dir.y
 }
 
-// Makes the guard go through the map and returns the number of unique positions visited
-// before the guard leaves the map. If the guard enters a loop, it will return an error.
-func (g *Guard) Go(mapp Map) (int, error) {
-	uniqueVisited := 0
+// Trace describes the route of the guard
+type Trace struct {
+	Start      Guard
+	Turns      []Guard // The guard at each turn, already facing the new direction
+	End        Guard   // The guard right before leaving the map, or when the loop is detected
+	Visited    int     // Number of unique positions visited
+	Loop       bool    // Whether the guard got stuck in a loop
+	LoopEntry  Guard   // The first state of the guard repeated in the loop
+	LoopLength int     // The number of steps forward needed to go around the loop once
+}
+
+// Makes the guard go through the map and returns the route of the guard until it leaves the
+// map. If the guard enters a loop, it will return an error along with the route until then.
+// The guard jumps from turn to turn (see Jumps), only marking the positions in between.
+func (g *Guard) Go(mapp Map) (Trace, error) {
+	trace := Trace{Start: *g}
+	jumps := NewJumps(mapp)
 	for {
-		// First, mark out current position as visited
-		if !mapp[g.y][g.x].visited {
-			mapp[g.y][g.x].visited = true
-			mapp[g.y][g.x].dir = g.dir
-			uniqueVisited++
-		} else {
-			// The guard has visited this position before. Check if the direction is the same
-			if mapp[g.y][g.x].dir == g.dir {
-				return uniqueVisited, fmt.Errorf("loop detected at position (%d, %d)", g.x, g.y)
+		stop, inside := jumps.stop(*g)
+		for {
+			// First, mark out current position as visited
+			pos := &mapp[g.y][g.x]
+			if !pos.visited {
+				pos.visited = true
+				pos.dir = g.dir
+				trace.Visited++
 			}
+			// The guard has already been here facing the same direction, so it will keep
+			// repeating the same route
+			if pos.dirs&g.dir.mask() != 0 {
+				trace.End = *g
+				trace.Loop = true
+				trace.LoopEntry = *g
+				trace.LoopLength = g.loopLength(mapp)
+				return trace, fmt.Errorf("loop detected at position (%d, %d)", g.x, g.y)
+			}
+			pos.dirs |= g.dir.mask()
+			if *g == stop {
+				break
+			}
+			g.moveForward()
 		}
-		// The guard moves depending on the direction. There are two posibilites:
-		// 1. There is an obstacle in front of the guard.
-		// 2. There is no obstacle in front of the guard.
-		// If there is an obstacle, the guard will turn right. If there is no obstacle,
-		// the guard will move forward.
-		next := Position{x: g.x + g.dir.x, y: g.y + g.dir.y}
-		if mapp.outOfBounds(next) {
-			return uniqueVisited, nil
+		// The guard either leaves the map or bumps into an obstacle and turns right
+		if !inside {
+			trace.End = *g
+			return trace, nil
+		}
+		g.dir = g.dir.turnRight()
+		trace.Turns = append(trace.Turns, *g)
+	}
+}
+
+// loops reports whether the guard gets stuck in a loop. Only the states at the turns are
+// checked, stamping them in seen (indexed as Jumps.next) so it can be reused without resetting.
+func (g Guard) loops(jumps *Jumps, seen []int, stamp int) bool {
+	for {
+		stop, inside := jumps.stop(g)
+		if !inside {
+			return false
+		}
+		g = stop
+		state := jumps.at(g)
+		if seen[state] == stamp {
+			return true
+		}
+		seen[state] = stamp
+		g.dir = g.dir.turnRight()
+	}
+}
+
+// Jumps holds, for every position and direction, the next obstacle the guard bumps into, so the
+// guard goes from turn to turn without walking every position in between
+type Jumps struct {
+	width, height int
+	blocked       []bool
+	// Indexed as direction then position (see at), the coordinate of the next obstacle along
+	// the direction: x for LEFT and RIGHT, y for UP and DOWN. The border of the map is out of
+	// bounds (-1, width or height) when there are no more obstacles.
+	next []int
+}
+
+// NewJumps builds the jump table of the obstacles of the map
+func NewJumps(mapp Map) *Jumps {
+	j := &Jumps{width: len(mapp[0]), height: len(mapp)}
+	j.blocked = make([]bool, j.width*j.height)
+	j.next = make([]int, 4*j.width*j.height)
+	// Without obstacles, the guard walks up to the border
+	for _, dir := range []Dir{UP, RIGHT, DOWN, LEFT} {
+		border := -1
+		switch dir {
+		case RIGHT:
+			border = j.width
+		case DOWN:
+			border = j.height
+		}
+		for y := 0; y < j.height; y++ {
+			for x := 0; x < j.width; x++ {
+				j.next[j.at(Guard{x, y, dir})] = border
+			}
+		}
+	}
+	for y := range mapp {
+		for x, pos := range mapp[y] {
+			if pos.obstacle {
+				j.Add(x, y)
+			}
+		}
+	}
+	return j
+}
+
+// Clone returns a copy of the jump table, which can be updated independently
+func (j *Jumps) Clone() *Jumps {
+	clone := *j
+	clone.blocked = append([]bool(nil), j.blocked...)
+	clone.next = append([]int(nil), j.next...)
+	return &clone
+}
+
+// at returns the index in next of the position and direction of the guard
+func (j *Jumps) at(g Guard) int {
+	return (g.dir.index()*j.height+g.y)*j.width + g.x
+}
+
+// along returns the coordinate of the guard along its direction
+func (j *Jumps) along(g Guard) int {
+	if g.dir.x != 0 {
+		return g.x
+	}
+	return g.y
+}
+
+// stop returns where the guard stops, right before the next obstacle, and whether it is still
+// in the map (false when it walks out through the border)
+func (j *Jumps) stop(g Guard) (Guard, bool) {
+	next := j.next[j.at(g)]
+	if g.dir.x != 0 {
+		g.x = next - g.dir.x
+		return g, next >= 0 && next < j.width
+	}
+	g.y = next - g.dir.y
+	return g, next >= 0 && next < j.height
+}
+
+// Add inserts an obstacle, updating only the positions of its row and column which reach it
+func (j *Jumps) Add(x, y int) {
+	j.blocked[y*j.width+x] = true
+	for _, dir := range []Dir{UP, RIGHT, DOWN, LEFT} {
+		j.fill(Guard{x - dir.x, y - dir.y, dir}, j.along(Guard{x, y, dir}))
+	}
+}
+
+// Remove takes an obstacle out, undoing Add
+func (j *Jumps) Remove(x, y int) {
+	j.blocked[y*j.width+x] = false
+	for _, dir := range []Dir{UP, RIGHT, DOWN, LEFT} {
+		ahead := Guard{x + dir.x, y + dir.y, dir}
+		next := j.along(ahead)
+		if j.inside(ahead) && !j.blocked[ahead.y*j.width+ahead.x] {
+			next = j.next[j.at(ahead)]
 		}
+		j.fill(Guard{x, y, dir}, next)
+	}
+}
+
+// fill sets the next obstacle of the guard's direction, going backwards from the guard until
+// another obstacle or the border is found
+func (j *Jumps) fill(g Guard, next int) {
+	for ; j.inside(g) && !j.blocked[g.y*j.width+g.x]; g.x, g.y = g.x-g.dir.x, g.y-g.dir.y {
+		j.next[j.at(g)] = next
+	}
+}
+
+func (j *Jumps) inside(g Guard) bool {
+	return g.x >= 0 && g.y >= 0 && g.x < j.width && g.y < j.height
+}
+
+// loopLength counts the steps forward the guard takes to come back to its current state
+func (g Guard) loopLength(mapp Map) (steps int) {
+	start := g
+	for {
+		next := Position{x: g.x + g.dir.x, y: g.y + g.dir.y}
 		if mapp[next.y][next.x].obstacle {
-			// Turn right
-			g.dir = Dir{-g.dir.y, g.dir.x}
+			g.dir = g.dir.turnRight()
 		} else {
-			// Move forward
 			g.moveForward()
+			steps++
+		}
+		if g == start {
+			return
+		}
+	}
+}
+
+// Render draws the map along with the route followed by the guard: `|` and `-` for the
+// positions crossed vertically and horizontally, `+` for both (e.g. at turns), the guard at its
+// starting position and `@` at the entry of the loop, if any.
+func (m Map) Render(trace Trace) string {
+	var sb strings.Builder
+	for y, row := range m {
+		for x, pos := range row {
+			vertical := pos.dirs&(UP.mask()|DOWN.mask()) != 0
+			horizontal := pos.dirs&(LEFT.mask()|RIGHT.mask()) != 0
+			switch {
+			case trace.Loop && x == trace.LoopEntry.x && y == trace.LoopEntry.y:
+				sb.WriteByte('@')
+			case x == trace.Start.x && y == trace.Start.y:
+				sb.WriteString(trace.Start.dir.String())
+			case pos.obstacle:
+				sb.WriteByte('#')
+			case vertical && horizontal:
+				sb.WriteByte('+')
+			case vertical:
+				sb.WriteByte('|')
+			case horizontal:
+				sb.WriteByte('-')
+			default:
+				sb.WriteByte('.')
+			}
+		}
+		if y < len(m)-1 {
+			sb.WriteByte('\n')
 		}
 	}
+	return sb.String()
 }
 
 func (m Map) Clone() (clone Map) {
@@ -128,32 +353,68 @@ func (m Map) Clone() (clone Map) {
 
 func part1(input string) int {
 	mapp, guard := parseInput(input)
-	uniqueVisited, err := guard.Go(mapp)
+	trace, err := guard.Go(mapp)
 	if err != nil {
 		panic(err)
 	}
-	return uniqueVisited
+	return trace.Visited
+}
+
+// A candidate is a new obstacle, along with the state of the guard right before bumping into it
+type candidate struct {
+	x, y int
+	from Guard
 }
 
 func part2(input string) (loops int) {
 	mapp, guard := parseInput(input)
-	// We're going bruteforce here! We have to place an item in a location that causes the
-	// guard to go in a loop. We will check if the guard has visited the same position twice
-	// with the same direction, and if it has, we will break the loop.
-	for i := range mapp {
-		for j := range mapp[i] {
-			// If the current position matches the guard or it's already an obstacle, skip
-			if (guard.x == j && guard.y == i) || mapp[i][j].obstacle {
-				continue
-			}
-			tmp := mapp.Clone()
-			tmp[i][j].obstacle = true
-			tmpGuard := guard
-			_, err := tmpGuard.Go(tmp)
-			if err != nil {
-				loops++
+	// The new obstacle must be on the path of the guard, otherwise it is never hit. As the path
+	// is the same until the obstacle is reached, each simulation resumes from the step before
+	// it: the guard entered every position for the first time going in its recorded direction.
+	path := mapp.Clone()
+	walker := guard
+	if _, err := walker.Go(path); err != nil {
+		panic(err)
+	}
+	candidates := make(chan candidate)
+	go func() {
+		defer close(candidates)
+		for i := range path {
+			for j, pos := range path[i] {
+				if !pos.visited || (guard.x == j && guard.y == i) {
+					continue
+				}
+				from := Guard{x: j - pos.dir.x, y: i - pos.dir.y, dir: pos.dir}
+				candidates <- candidate{x: j, y: i, from: from}
 			}
 		}
+	}()
+
+	// Every worker has its own jump table, where the new obstacle is added and then removed
+	jumps := NewJumps(mapp)
+	workers := runtime.GOMAXPROCS(0)
+	counts := make([]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tmp := jumps.Clone()
+			seen := make([]int, len(tmp.next))
+			stamp := 0
+			for c := range candidates {
+				stamp++
+				tmp.Add(c.x, c.y)
+				if c.from.loops(tmp, seen, stamp) {
+					counts[w]++
+				}
+				tmp.Remove(c.x, c.y)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, count := range counts {
+		loops += count
 	}
 	return
 }
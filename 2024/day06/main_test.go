@@ -2,7 +2,10 @@ package main
 
 import (
 	"log/slog"
+	"reflect"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `....#.....
@@ -36,6 +39,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -63,9 +69,176 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestGuard_Go(t *testing.T) {
+	tests := []struct {
+		name     string
+		obstacle *Position // Extra obstacle, if any
+		want     Trace
+		render   string
+	}{
+		{
+			name: "leaves the map",
+			want: Trace{Visited: 41, End: Guard{7, 9, DOWN}},
+			render: `....#.....
+....+---+#
+....|...|.
+..#.|...|.
+..+-+-+#|.
+..|.|.|.|.
+.#+-^-+-+.
+.+----++#.
+#+----+|..
+......#|..`,
+		},
+		{
+			name:     "loop",
+			obstacle: &Position{x: 3, y: 6},
+			want: Trace{
+				Visited:    18,
+				End:        Guard{4, 6, UP},
+				Loop:       true,
+				LoopEntry:  Guard{4, 6, UP},
+				LoopLength: 18,
+			},
+			render: `....#.....
+....+---+#
+....|...|.
+..#.|...|.
+....|..#|.
+....|...|.
+.#.#@---+.
+........#.
+#.........
+......#...`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapp, guard := parseInput(example)
+			if tt.obstacle != nil {
+				mapp[tt.obstacle.y][tt.obstacle.x].obstacle = true
+			}
+			got, err := guard.Go(mapp)
+			if (err != nil) != tt.want.Loop {
+				t.Fatalf("Go() error = %v, want loop %v", err, tt.want.Loop)
+			}
+			if got.Visited != tt.want.Visited || got.End != tt.want.End || got.Loop != tt.want.Loop ||
+				got.LoopEntry != tt.want.LoopEntry || got.LoopLength != tt.want.LoopLength {
+				t.Errorf("Go() = %+v, want %+v", got, tt.want)
+			}
+			if render := mapp.Render(got); render != tt.render {
+				t.Errorf("Render() =\n%s\nwant\n%s", render, tt.render)
+			}
+		})
+	}
+}
+
+func TestJumps_Add(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y int
+	}{
+		{"corner", 0, 0},
+		{"border", 9, 5},
+		{"next to obstacle", 5, 0},
+		{"between obstacles", 3, 6},
+		{"open", 5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapp, _ := parseInput(example)
+			jumps := NewJumps(mapp)
+			got := jumps.Clone()
+			got.Add(tt.x, tt.y)
+			mapp[tt.y][tt.x].obstacle = true
+			if want := NewJumps(mapp); !sameStops(got, want) {
+				t.Errorf("Add(%d, %d) differs from building the table with the obstacle", tt.x, tt.y)
+			}
+			got.Remove(tt.x, tt.y)
+			if !sameStops(got, jumps) {
+				t.Errorf("Remove(%d, %d) doesn't restore the table", tt.x, tt.y)
+			}
+		})
+	}
+}
+
+// sameStops compares where the guard stops from every free position, as the obstacles keep
+// whatever was in the table before they were added
+func sameStops(a, b *Jumps) bool {
+	if !reflect.DeepEqual(a.blocked, b.blocked) {
+		return false
+	}
+	for y := 0; y < a.height; y++ {
+		for x := 0; x < a.width; x++ {
+			if a.blocked[y*a.width+x] {
+				continue
+			}
+			for _, dir := range []Dir{UP, RIGHT, DOWN, LEFT} {
+				g := Guard{x, y, dir}
+				stopA, insideA := a.stop(g)
+				stopB, insideB := b.stop(g)
+				if stopA != stopB || insideA != insideB {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
+
+// bruteForceImpl tries every free position of the map as the new obstacle, sequentially
+func bruteForceImpl(input string) (loops int) {
+	mapp, guard := parseInput(input)
+	for i := range mapp {
+		for j := range mapp[i] {
+			if (guard.x == j && guard.y == i) || mapp[i][j].obstacle {
+				continue
+			}
+			tmp := mapp.Clone()
+			tmp[i][j].obstacle = true
+			tmpGuard := guard
+			if _, err := tmpGuard.Go(tmp); err != nil {
+				loops++
+			}
+		}
+	}
+	return
+}
+
+func Test_bruteForceImpl(t *testing.T) {
+	if got, want := bruteForceImpl(example), part2(example); got != want {
+		t.Errorf("bruteForceImpl() = %v, part2() = %v", got, want)
+	}
+}
+
+func BenchmarkBruteForce(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
+	for i := 0; i < b.N; i++ {
+		bruteForceImpl(input)
+	}
+}
+
+func BenchmarkPath(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
+	for i := 0; i < b.N; i++ {
+		part2(input)
+	}
+}
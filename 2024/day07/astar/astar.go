@@ -103,9 +103,9 @@ func (g *Grid) AStar(exhaustive bool) Path {
 
 		// Get the neighbors of the current node
 		for _, neighbor := range current.finder.Neighbors(g) {
-			cost := neighbor.operation.Cal(current.cost, neighbor.value)
+			cost, err := neighbor.operation.Apply(current.cost, neighbor.value)
 			// Skip if the cost is invalid
-			if !g.IsValidCost(cost) {
+			if err != nil || !g.IsValidCost(cost) {
 				continue
 			}
 			neighborNode := nm.Get(neighbor)
@@ -1,27 +1,25 @@
 package main
 
 import (
-	_ "embed"
+	"errors"
 	"flag"
 	"fmt"
+	"log"
 	"log/slog"
+	"math/big"
 	"strings"
 
-	"github.com/Javinator9889/aoc-2024/2024/day07/astar"
 	"github.com/Javinator9889/aoc-2024/2024/day07/ops"
-	"github.com/Javinator9889/aoc-2024/cast"
+	"github.com/Javinator9889/aoc-2024/2024/day07/solver"
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-//go:embed input.txt
 var input string
+var useBig bool
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
@@ -29,12 +27,25 @@ func main() {
 	var debug bool
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
 	flag.BoolVar(&debug, "debug", false, "debug mode")
+	readInput := util.InputFlags(flag.CommandLine)
+	flag.BoolVar(&useBig, "big", false, "solve with arbitrary precision integers (math/big)")
 	flag.Parse()
+	input = readInput()
+	if part < 1 || part > len(OPS) {
+		log.Fatalf("invalid part %d, expected 1 or 2", part)
+	}
 	fmt.Println("Running part", part)
 	if debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
 	}
 
+	if useBig {
+		// The answer may not fit in an int either
+		ans := calibrate(input, OPS[part-1])
+		util.CopyToClipboard(ans.String())
+		fmt.Println("Output:", ans)
+		return
+	}
 	if part == 1 {
 		ans := part1(input)
 		util.CopyToClipboard(fmt.Sprintf("%v", ans))
@@ -46,82 +57,96 @@ func main() {
 	}
 }
 
+// OPS are the valid operations of each part
+var OPS = [][]ops.Op{
+	{ops.ADD, ops.MUL},
+	{ops.ADD, ops.MUL, ops.CONCAT},
+}
+
 type Row struct {
 	value   int
 	numbers []int
+	big     solver.BigEquation // The same row with arbitrary precision
+	fits    bool               // Whether every value of the row fits in an int
 }
 
-func part1(input string) (solvable int) {
-	// We can consider this exercise as a graph problem, where each node is a number with
-	// a set of valid operations to apply to. The goal is to reach a certain number by using
-	// all the numbers in the array. We can use the A* algorithm to find the path to the goal
-	// by considering the next number in the array as the reference. The next states are built
-	// by combining the set of valid operations with the next number in the array.
-	// The cost of the operation is the value of the number, and the heuristic is the position
-	// of the number in the array. The algorithm stops when the cost of the operation is greater
-	// than the goal.
-	// The algorithm is implemented in the astar package. The `IsValidCost` verifies the obtained
-	// cost does not exceed the goal.
-	parsed := parseInput(input)
-	for _, row := range parsed {
-		grid := astar.Grid{
-			Goal:     row.value,
-			Numbers:  row.numbers,
-			ValidOps: []ops.Op{ops.ADD, ops.MUL},
-			IsValidCost: func(cost int) bool {
-				return cost <= row.value
-			},
-		}
-		path := grid.AStar(true /* exhaustive */)
-		// We have to use all the numbers
-		if path != nil && len(path) == len(row.numbers) {
-			slog.Debug("Path for", "n", row.value, "path", path)
-			solvable += row.value
-		} else if path != nil {
-			slog.Warn("Not using all numbers", "n", row.value, "path", path, "nums", row.numbers)
-		}
-	}
-	return
+func part1(input string) int {
+	// Each equation is solved backwards from its value (see solver.Solver), undoing the
+	// operations with the last number until only the first one is left
+	return toInt(calibrate(input, OPS[0]))
 }
 
-func part2(input string) (solvable int) {
+func part2(input string) int {
 	// Part 2 is an extension of part 1, but with an extra operation: Concatenation. Just repeat
 	// the process of part 1, but adding the new operation to the set of valid operations.
-	parsed := parseInput(input)
-	for _, row := range parsed {
-		grid := astar.Grid{
-			Goal:     row.value,
-			Numbers:  row.numbers,
-			ValidOps: []ops.Op{ops.ADD, ops.MUL, ops.CONCAT},
-			IsValidCost: func(cost int) bool {
-				return cost <= row.value
-			},
+	return toInt(calibrate(input, OPS[1]))
+}
+
+// calibrate sums the values of the rows which can be solved with the given operations
+func calibrate(input string, valid []ops.Op) *big.Int {
+	s := solver.Solver{Ops: valid}
+	total := new(big.Int)
+	for _, row := range parseInput(input) {
+		ok, err := solvable(s, row)
+		if err != nil {
+			panic(err)
 		}
-		path := grid.AStar(true /* exhaustive */)
-		// We have to use all the numbers
-		if path != nil && len(path) == len(row.numbers) {
-			slog.Debug("Path for", "n", row.value, "path", path)
-			solvable += row.value
-		} else if path != nil {
-			slog.Warn("Not using all numbers", "n", row.value, "path", path, "nums", row.numbers)
+		if ok {
+			total.Add(total, row.big.Goal)
 		}
 	}
-	return
+	return total
+}
+
+// solvable solves the row with ints, unless it doesn't fit or -big is set. If any intermediate
+// value overflows, the row is solved again with big integers.
+func solvable(s solver.Solver, row Row) (bool, error) {
+	if !useBig && row.fits {
+		ok, err := s.Solvable(solver.Equation{Goal: row.value, Numbers: row.numbers})
+		if !errors.Is(err, ops.ErrOverflow) {
+			return ok, err
+		}
+		slog.Debug("Overflow, solving with big integers", "value", row.value, "numbers", row.numbers)
+	}
+	return s.SolvableBig(row.big)
+}
+
+func toInt(n *big.Int) int {
+	if !n.IsInt64() {
+		panic(fmt.Sprintf("%s doesn't fit in an int, run with -big", n))
+	}
+	return int(n.Int64())
 }
 
 func parseInput(input string) (ans []Row) {
 	ans = make([]Row, 0)
 	for _, line := range strings.Split(input, "\n") {
 		items := strings.Split(line, ": ")
-		k, v := cast.ToInt(items[0]), strings.Split(items[1], " ")
+		v := strings.Split(items[1], " ")
 		row := Row{
-			value:   k,
 			numbers: make([]int, len(v)),
+			big:     solver.BigEquation{Numbers: make([]*big.Int, len(v))},
+			fits:    true,
 		}
+		row.value, row.big.Goal = parseNumber(items[0], &row.fits)
 		for i := range v {
-			row.numbers[i] = cast.ToInt(v[i])
+			row.numbers[i], row.big.Numbers[i] = parseNumber(v[i], &row.fits)
 		}
 		ans = append(ans, row)
 	}
 	return
 }
+
+// parseNumber parses the number both as an int and as a big integer, clearing fits if it
+// doesn't fit in an int
+func parseNumber(s string, fits *bool) (int, *big.Int) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid number " + s)
+	}
+	if !n.IsInt64() {
+		*fits = false
+		return 0, n
+	}
+	return int(n.Int64()), n
+}
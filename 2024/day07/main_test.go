@@ -3,6 +3,10 @@ package main
 import (
 	"log/slog"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/2024/day07/astar"
+	"github.com/Javinator9889/aoc-2024/2024/day07/ops"
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `190: 10 19
@@ -15,6 +19,14 @@ var example = `190: 10 19
 21037: 9 7 18 13
 292: 11 6 16 20`
 
+// Needs intermediate values which don't fit in an int: (MaxInt + 2) * 0 + 5
+var overflowing = `5: 9223372036854775807 2 0 5
+3: 1 2`
+
+// The value of the first row doesn't fit in an int
+var huge = `92233720368547758070: 922337203685477580 70
+190: 10 19`
+
 func Test_part1(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -26,6 +38,11 @@ func Test_part1(t *testing.T) {
 			input: example,
 			want:  3749,
 		},
+		{
+			name:  "overflowing",
+			input: overflowing,
+			want:  8,
+		},
 		{
 			name:  "actual",
 			input: input,
@@ -35,6 +52,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -53,6 +73,11 @@ func Test_part2(t *testing.T) {
 			input: example,
 			want:  11387,
 		},
+		{
+			name:  "overflowing",
+			input: overflowing,
+			want:  8,
+		},
 		{
 			name:  "actual",
 			input: input,
@@ -62,9 +87,85 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
+
+func Test_calibrate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		part   int
+		useBig bool
+		want   string
+	}{
+		{"example", example, 2, true, "11387"},
+		{"overflowing", overflowing, 1, false, "8"},
+		{"overflowing big", overflowing, 1, true, "8"},
+		{"huge", huge, 1, false, "190"},
+		{"huge concat", huge, 2, false, "92233720368547758260"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(old bool) { useBig = old }(useBig)
+			useBig = tt.useBig
+			if got := calibrate(tt.input, OPS[tt.part-1]); got.String() != tt.want {
+				t.Errorf("calibrate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// astarImpl explores the equations forward from the first number, using the A* search
+func astarImpl(input string, validOps []ops.Op) (solvable int) {
+	for _, row := range parseInput(input) {
+		grid := astar.Grid{
+			Goal:     row.value,
+			Numbers:  row.numbers,
+			ValidOps: validOps,
+			IsValidCost: func(cost int) bool {
+				return cost <= row.value
+			},
+		}
+		path := grid.AStar(true /* exhaustive */)
+		// We have to use all the numbers
+		if path != nil && len(path) == len(row.numbers) {
+			solvable += row.value
+		}
+	}
+	return
+}
+
+func Test_astarImpl(t *testing.T) {
+	if got := astarImpl(example, []ops.Op{ops.ADD, ops.MUL, ops.CONCAT}); got != 11387 {
+		t.Errorf("astarImpl() = %v, want %v", got, 11387)
+	}
+}
+
+func BenchmarkAStar(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
+	for i := 0; i < b.N; i++ {
+		astarImpl(input, []ops.Op{ops.ADD, ops.MUL, ops.CONCAT})
+	}
+}
+
+func BenchmarkBackward(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
+	for i := 0; i < b.N; i++ {
+		part2(input)
+	}
+}
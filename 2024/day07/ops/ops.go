@@ -1,10 +1,12 @@
 package ops
 
 import (
-	"strconv"
-	"strings"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
 
-	"github.com/Javinator9889/aoc-2024/cast"
+	"github.com/Javinator9889/aoc-2024/util"
 )
 
 const (
@@ -15,41 +17,355 @@ const (
 	CONCAT = "concat"
 )
 
+var (
+	ErrUnknown   = errors.New("unknown operation")
+	ErrOverflow  = util.ErrOverflow
+	ErrInvalid   = errors.New("invalid operands")               // e.g. dividing by zero
+	ErrNoInverse = errors.New("operation can't be undone here") // e.g. multiplying by zero
+)
+
+// Op is the name of an operation, registered along with its Operator
 type Op string
 
-// Performs the operation between two numbers
-func (o Op) Cal(a, b int) int {
-	switch o {
-	case ADD:
-		return a + b
-	case SUB:
-		return a - b
-	case MUL:
-		return a * b
-	case DIV:
-		return a / b
-	case CONCAT:
-		// Concatenation is a special case where we have to join the numbers
-		// It adds very little value calculating the size of the numbers, so just join them
-		// as strings and parse the result
-		numbers := []string{strconv.Itoa(a), strconv.Itoa(b)}
-		return cast.ToInt(strings.Join(numbers, ""))
-	}
-	panic("invalid operation")
+// An Operator describes how an operation works. Only Symbol and Apply are required.
+type Operator struct {
+	Symbol     string // How the operation is written, e.g. "+"
+	Precedence int    // Higher precedences are evaluated first when writing expressions
+	// Apply performs the operation between two numbers, failing instead of overflowing
+	Apply func(a, b int) (int, error)
+	// Inverse returns every a such that Apply(a, b) == result. The values may not be valid, so
+	// they must be checked with Apply. ErrNoInverse is returned when they can't be told apart.
+	Inverse func(result, b int) ([]int, error)
+	// ApplyBig and InverseBig are the same as Apply and Inverse with arbitrary precision, so
+	// they never overflow. ApplyBig is required to solve equations with big integers.
+	ApplyBig   func(a, b *big.Int) (*big.Int, error)
+	InverseBig func(result, b *big.Int) ([]*big.Int, error)
+}
+
+// Dividing by larger numbers is undone by evaluating forward, as there are too many candidates
+const MAX_DIV_SPREAD = 64
+
+// The registered operators, by name
+var operators = map[Op]Operator{
+	ADD: {
+		Symbol: "+", Precedence: 1, Apply: util.CheckedAdd, Inverse: undoAdd,
+		ApplyBig: addBig, InverseBig: undoAddBig,
+	},
+	SUB: {
+		Symbol: "-", Precedence: 1, Apply: util.CheckedSub, Inverse: undoSub,
+		ApplyBig: subBig, InverseBig: undoSubBig,
+	},
+	MUL: {
+		Symbol: "*", Precedence: 2, Apply: util.CheckedMul, Inverse: undoMul,
+		ApplyBig: mulBig, InverseBig: undoMulBig,
+	},
+	DIV: {
+		Symbol: "/", Precedence: 2, Apply: div, Inverse: undoDiv,
+		ApplyBig: divBig, InverseBig: undoDivBig,
+	},
+	CONCAT: {
+		Symbol: "||", Precedence: 3, Apply: concat, Inverse: undoConcat,
+		ApplyBig: concatBig, InverseBig: undoConcatBig,
+	},
+}
+
+// Register adds a new operation, which can be used right away by its name or parsed from its
+// symbol. It must not be called while other operations are being performed.
+func Register(op Op, o Operator) error {
+	if o.Symbol == "" || o.Apply == nil {
+		return fmt.Errorf("registering %q: symbol and apply are required", op)
+	}
+	if _, ok := operators[op]; ok {
+		return fmt.Errorf("registering %q: already registered", op)
+	}
+	if existing, err := Parse(o.Symbol); err == nil {
+		return fmt.Errorf("registering %q: symbol %q is taken by %q", op, o.Symbol, existing)
+	}
+	operators[op] = o
+	return nil
+}
+
+// Unregister removes an operation added with Register
+func Unregister(op Op) {
+	delete(operators, op)
+}
+
+// Parse returns the operation written with the given symbol
+func Parse(symbol string) (Op, error) {
+	for op, o := range operators {
+		if o.Symbol == symbol {
+			return op, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknown, symbol)
+}
+
+// Operator returns the description of the operation, if registered
+func (o Op) Operator() (Operator, bool) {
+	operator, ok := operators[o]
+	return operator, ok
+}
+
+// Apply performs the operation between two numbers
+func (o Op) Apply(a, b int) (int, error) {
+	operator, ok := operators[o]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknown, string(o))
+	}
+	return operator.Apply(a, b)
+}
+
+// Inverse returns the values a for which `a o b == result`, see Operator.Inverse
+func (o Op) Inverse(result, b int) ([]int, error) {
+	operator, ok := operators[o]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknown, string(o))
+	}
+	if operator.Inverse == nil {
+		return nil, ErrNoInverse
+	}
+	return operator.Inverse(result, b)
+}
+
+// ApplyBig performs the operation between two big integers
+func (o Op) ApplyBig(a, b *big.Int) (*big.Int, error) {
+	operator, ok := operators[o]
+	if !ok || operator.ApplyBig == nil {
+		return nil, fmt.Errorf("%w with big integers: %q", ErrUnknown, string(o))
+	}
+	return operator.ApplyBig(a, b)
+}
+
+// InverseBig returns the values a for which `a o b == result`, see Operator.Inverse
+func (o Op) InverseBig(result, b *big.Int) ([]*big.Int, error) {
+	operator, ok := operators[o]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknown, string(o))
+	}
+	if operator.InverseBig == nil {
+		return nil, ErrNoInverse
+	}
+	return operator.InverseBig(result, b)
+}
+
+// Precedence returns the precedence of the operation, 0 if unknown
+func (o Op) Precedence() int {
+	return operators[o].Precedence
 }
 
 func (o Op) String() string {
-	switch o {
-	case ADD:
-		return "+"
-	case SUB:
-		return "-"
-	case MUL:
-		return "*"
-	case DIV:
-		return "/"
-	case CONCAT:
-		return "||"
-	}
-	panic("invalid operation")
+	if operator, ok := operators[o]; ok {
+		return operator.Symbol
+	}
+	return string(o)
+}
+
+func div(a, b int) (int, error) {
+	switch {
+	case b == 0:
+		return 0, fmt.Errorf("%w: division by zero", ErrInvalid)
+	case a == math.MinInt && b == -1:
+		return 0, ErrOverflow
+	}
+	return a / b, nil
+}
+
+// shift returns the power of 10 which makes room for the digits of n, e.g. 100 for 42
+func shift(n int) (int, error) {
+	s := 10
+	for ; n >= 10; n /= 10 {
+		if s > math.MaxInt/10 {
+			return 0, ErrOverflow
+		}
+		s *= 10
+	}
+	return s, nil
+}
+
+// concat joins the digits of both numbers, e.g. 12 || 345 = 12345. The sign is the one of a.
+func concat(a, b int) (int, error) {
+	if b < 0 {
+		return 0, fmt.Errorf("%w: concatenating a negative number", ErrInvalid)
+	}
+	s, err := shift(b)
+	if err != nil {
+		if a == 0 {
+			return b, nil
+		}
+		return 0, err
+	}
+	r, err := util.CheckedMul(a, s)
+	if err != nil {
+		return 0, err
+	}
+	if a < 0 {
+		return util.CheckedSub(r, b)
+	}
+	return util.CheckedAdd(r, b)
+}
+
+func undoAdd(result, b int) ([]int, error) {
+	a, err := util.CheckedSub(result, b)
+	if err != nil {
+		return nil, err
+	}
+	return []int{a}, nil
+}
+
+func undoSub(result, b int) ([]int, error) {
+	a, err := util.CheckedAdd(result, b)
+	if err != nil {
+		return nil, err
+	}
+	return []int{a}, nil
+}
+
+func undoMul(result, b int) ([]int, error) {
+	switch {
+	case b == 0:
+		return nil, ErrNoInverse
+	case result == math.MinInt && b == -1:
+		return nil, ErrOverflow
+	case result%b != 0:
+		return nil, nil
+	}
+	return []int{result / b}, nil
+}
+
+// undoDiv returns every value up to |b| - 1 away from result*b, as the division truncates
+func undoDiv(result, b int) ([]int, error) {
+	spread := max(b, -b) - 1
+	switch {
+	case b == 0:
+		return nil, nil
+	case spread > MAX_DIV_SPREAD:
+		return nil, ErrNoInverse
+	}
+	a, err := util.CheckedMul(result, b)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []int
+	for d := -spread; d <= spread; d++ {
+		if c, err := util.CheckedAdd(a, d); err == nil {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates, nil
+}
+
+// undoConcat strips the digits of b from the end of result
+func undoConcat(result, b int) ([]int, error) {
+	if b < 0 {
+		return nil, nil
+	}
+	sign, abs := 1, result
+	if result < 0 {
+		if result == math.MinInt {
+			return nil, ErrOverflow
+		}
+		sign, abs = -1, -result
+	}
+	s, err := shift(b)
+	switch {
+	case abs < b:
+		return nil, nil
+	case err != nil:
+		// Only 0 makes room for such a long number
+		if abs == b {
+			return []int{0}, nil
+		}
+		return nil, nil
+	case (abs-b)%s != 0:
+		return nil, nil
+	}
+	return []int{sign * ((abs - b) / s)}, nil
+}
+
+func addBig(a, b *big.Int) (*big.Int, error) {
+	return new(big.Int).Add(a, b), nil
+}
+
+func subBig(a, b *big.Int) (*big.Int, error) {
+	return new(big.Int).Sub(a, b), nil
+}
+
+func mulBig(a, b *big.Int) (*big.Int, error) {
+	return new(big.Int).Mul(a, b), nil
+}
+
+// divBig truncates towards zero, as div does
+func divBig(a, b *big.Int) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, fmt.Errorf("%w: division by zero", ErrInvalid)
+	}
+	return new(big.Int).Quo(a, b), nil
+}
+
+// shiftBig returns the power of 10 which makes room for the digits of n, see shift
+func shiftBig(n *big.Int) *big.Int {
+	digits := len(n.Text(10))
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+}
+
+func concatBig(a, b *big.Int) (*big.Int, error) {
+	if b.Sign() < 0 {
+		return nil, fmt.Errorf("%w: concatenating a negative number", ErrInvalid)
+	}
+	r := new(big.Int).Mul(a, shiftBig(b))
+	if a.Sign() < 0 {
+		return r.Sub(r, b), nil
+	}
+	return r.Add(r, b), nil
+}
+
+func undoAddBig(result, b *big.Int) ([]*big.Int, error) {
+	return []*big.Int{new(big.Int).Sub(result, b)}, nil
+}
+
+func undoSubBig(result, b *big.Int) ([]*big.Int, error) {
+	return []*big.Int{new(big.Int).Add(result, b)}, nil
+}
+
+func undoMulBig(result, b *big.Int) ([]*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrNoInverse
+	}
+	a, rem := new(big.Int).QuoRem(result, b, new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, nil
+	}
+	return []*big.Int{a}, nil
+}
+
+func undoDivBig(result, b *big.Int) ([]*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, nil
+	}
+	abs := new(big.Int).Abs(b)
+	if abs.Cmp(big.NewInt(MAX_DIV_SPREAD+1)) > 0 {
+		return nil, ErrNoInverse
+	}
+	spread := abs.Int64() - 1
+	a := new(big.Int).Mul(result, b)
+	var candidates []*big.Int
+	for d := -spread; d <= spread; d++ {
+		candidates = append(candidates, new(big.Int).Add(a, big.NewInt(d)))
+	}
+	return candidates, nil
+}
+
+func undoConcatBig(result, b *big.Int) ([]*big.Int, error) {
+	abs := new(big.Int).Abs(result)
+	if b.Sign() < 0 || abs.Cmp(b) < 0 {
+		return nil, nil
+	}
+	a, rem := new(big.Int).QuoRem(abs.Sub(abs, b), shiftBig(b), new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, nil
+	}
+	if result.Sign() < 0 {
+		a.Neg(a)
+	}
+	return []*big.Int{a}, nil
 }
@@ -0,0 +1,105 @@
+package ops
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestOp_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      Op
+		a, b    int
+		want    int
+		wantErr error
+	}{
+		{"concat", CONCAT, 12, 345, 12345, nil},
+		{"concat zero", CONCAT, 0, 5, 5, nil},
+		{"concat ten", CONCAT, 1, 10, 110, nil},
+		{"concat negative", CONCAT, -3, 5, -35, nil},
+		{"concat negative operand", CONCAT, 3, -5, 0, ErrInvalid},
+		{"concat overflow", CONCAT, 1, math.MaxInt, 0, ErrOverflow},
+		{"add overflow", ADD, math.MaxInt, 1, 0, ErrOverflow},
+		{"sub overflow", SUB, math.MinInt, 1, 0, ErrOverflow},
+		{"mul overflow", MUL, math.MaxInt/2 + 1, 2, 0, ErrOverflow},
+		{"mul", MUL, -4, 5, -20, nil},
+		{"div by zero", DIV, 1, 0, 0, ErrInvalid},
+		{"div overflow", DIV, math.MinInt, -1, 0, ErrOverflow},
+		{"unknown", Op("pow"), 2, 3, 0, ErrUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op.Apply(tt.a, tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Apply() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		symbol  string
+		want    Op
+		wantErr bool
+	}{
+		{"+", ADD, false},
+		{"||", CONCAT, false},
+		{"^", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			got, err := Parse(tt.symbol)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("Parse() = %v, %v, want %v (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	const MOD = Op("mod")
+	err := Register(MOD, Operator{
+		Symbol:     "%",
+		Precedence: 2,
+		Apply:      mod,
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	defer Unregister(MOD)
+	if err := Register("plus", Operator{Symbol: "+", Apply: nil}); err == nil {
+		t.Errorf("Register() without apply should fail")
+	}
+	if err := Register("modulo", Operator{Symbol: "%", Apply: mod}); err == nil {
+		t.Errorf("Register() with a taken symbol should fail")
+	}
+	if err := Register(MOD, Operator{Symbol: "mod", Apply: mod}); err == nil {
+		t.Errorf("Register() of a registered operation should fail")
+	}
+
+	if op, err := Parse("%"); err != nil || op != MOD {
+		t.Errorf("Parse() = %v, %v, want %v", op, err, MOD)
+	}
+	if got, err := MOD.Apply(7, 3); err != nil || got != 1 {
+		t.Errorf("Apply() = %v, %v, want %v", got, err, 1)
+	}
+	if _, err := MOD.Inverse(1, 3); !errors.Is(err, ErrNoInverse) {
+		t.Errorf("Inverse() error = %v, want %v", err, ErrNoInverse)
+	}
+	if _, err := MOD.ApplyBig(big.NewInt(7), big.NewInt(3)); !errors.Is(err, ErrUnknown) {
+		t.Errorf("ApplyBig() error = %v, want %v", err, ErrUnknown)
+	}
+}
+
+func mod(a, b int) (int, error) {
+	if b == 0 {
+		return 0, ErrInvalid
+	}
+	return a % b, nil
+}
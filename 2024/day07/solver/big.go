@@ -0,0 +1,136 @@
+package solver
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Javinator9889/aoc-2024/2024/day07/ops"
+)
+
+// A BigEquation is an Equation with arbitrary precision, for values which don't fit in an int
+type BigEquation struct {
+	Goal    *big.Int
+	Numbers []*big.Int
+}
+
+// Big returns the equation with arbitrary precision
+func (e Equation) Big() BigEquation {
+	b := BigEquation{Goal: big.NewInt(int64(e.Goal)), Numbers: make([]*big.Int, len(e.Numbers))}
+	for i, n := range e.Numbers {
+		b.Numbers[i] = big.NewInt(int64(n))
+	}
+	return b
+}
+
+// SolveBig is the same as Solve, with arbitrary precision so nothing overflows
+func (s Solver) SolveBig(e BigEquation) (solutions []Assignment, err error) {
+	err = s.searchBig(e, func(a Assignment) bool {
+		solutions = append(solutions, append(Assignment(nil), a...))
+		return true
+	})
+	return
+}
+
+// SolvableBig is the same as Solvable, with arbitrary precision so nothing overflows
+func (s Solver) SolvableBig(e BigEquation) (found bool, err error) {
+	err = s.searchBig(e, func(Assignment) bool {
+		found = true
+		return false
+	})
+	if found {
+		err = nil
+	}
+	return
+}
+
+// searchBig goes backwards from the goal, as search does. The operators must support big
+// integers (see ops.Operator), otherwise their error is returned.
+func (s Solver) searchBig(e BigEquation, yield func(Assignment) bool) (err error) {
+	if len(e.Numbers) == 0 {
+		return nil
+	}
+	monotone := true
+	for _, op := range s.Ops {
+		monotone = monotone && (op == ops.ADD || op == ops.MUL || op == ops.CONCAT)
+	}
+	for _, n := range e.Numbers {
+		monotone = monotone && n.Sign() >= 0
+	}
+	// apply checks the operation, keeping the first error which isn't caused by the operands
+	apply := func(op ops.Op, a, b *big.Int) (*big.Int, bool) {
+		result, applyErr := op.ApplyBig(a, b)
+		if applyErr != nil && !errors.Is(applyErr, ops.ErrInvalid) && err == nil {
+			err = applyErr
+		}
+		return result, applyErr == nil
+	}
+	assignment := make(Assignment, len(e.Numbers)-1)
+	var backward func(i int, goal *big.Int) bool
+	backward = func(i int, goal *big.Int) bool {
+		if i == 0 {
+			return e.Numbers[0].Cmp(goal) != 0 || yield(assignment)
+		}
+		if monotone && goal.Sign() < 0 {
+			return true
+		}
+		b := e.Numbers[i]
+		for _, op := range s.Ops {
+			assignment[i-1] = op
+			candidates, inverseErr := op.InverseBig(goal, b)
+			if inverseErr != nil && !errors.Is(inverseErr, ops.ErrNoInverse) {
+				if err == nil {
+					err = inverseErr
+				}
+				continue
+			}
+			if inverseErr != nil {
+				cont := s.forwardBig(e.Numbers[:i], assignment, apply, func(value *big.Int) bool {
+					if result, ok := apply(op, value, b); ok && result.Cmp(goal) == 0 {
+						return yield(assignment)
+					}
+					return true
+				})
+				if !cont {
+					return false
+				}
+				continue
+			}
+			for _, a := range candidates {
+				if result, ok := apply(op, a, b); !ok || result.Cmp(goal) != 0 {
+					continue
+				}
+				if !backward(i-1, a) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	backward(len(e.Numbers)-1, e.Goal)
+	return
+}
+
+// forwardBig evaluates every assignment of operators of the numbers, as forward does
+func (s Solver) forwardBig(
+	numbers []*big.Int, assignment Assignment, apply func(ops.Op, *big.Int, *big.Int) (*big.Int, bool),
+	yield func(*big.Int) bool,
+) bool {
+	var next func(i int, value *big.Int) bool
+	next = func(i int, value *big.Int) bool {
+		if i == len(numbers) {
+			return yield(value)
+		}
+		for _, op := range s.Ops {
+			result, ok := apply(op, value, numbers[i])
+			if !ok {
+				continue
+			}
+			assignment[i-1] = op
+			if !next(i+1, result) {
+				return false
+			}
+		}
+		return true
+	}
+	return next(1, numbers[0])
+}
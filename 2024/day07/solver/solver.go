@@ -0,0 +1,169 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Javinator9889/aoc-2024/2024/day07/ops"
+)
+
+// An Equation must yield the Goal by evaluating its Numbers left to right, with one operator
+// between each pair of them
+type Equation struct {
+	Goal    int
+	Numbers []int
+}
+
+// An Assignment is the operator placed between each pair of numbers of an equation
+type Assignment []ops.Op
+
+// Expression writes the equation with the given operators. As the equation is evaluated left
+// to right, parentheses are added where the precedence of the operators would say otherwise,
+// e.g. "(81 + 40) * 27".
+func (e Equation) Expression(a Assignment) string {
+	var sb strings.Builder
+	loosest := math.MaxInt // The lowest precedence not between parentheses yet
+	for i, n := range e.Numbers {
+		if i > 0 {
+			op := a[i-1]
+			if loosest < op.Precedence() {
+				sb.WriteString(")")
+				loosest = math.MaxInt
+			}
+			sb.WriteString(" " + op.String() + " ")
+			loosest = min(loosest, op.Precedence())
+		}
+		sb.WriteString(strconv.Itoa(n))
+	}
+	// Open as many parentheses as were closed
+	opening := strings.Repeat("(", strings.Count(sb.String(), ")"))
+	return opening + sb.String()
+}
+
+// A Solver finds the operators which make equations true, out of its set of operators
+type Solver struct {
+	Ops []ops.Op
+}
+
+// Solve returns every assignment of operators which yields the goal of the equation. If any
+// intermediate value doesn't fit in an int, ops.ErrOverflow is returned along with the
+// assignments found, which may not be all of them.
+func (s Solver) Solve(e Equation) (solutions []Assignment, err error) {
+	err = s.search(e, func(a Assignment) bool {
+		solutions = append(solutions, append(Assignment(nil), a...))
+		return true
+	})
+	return
+}
+
+// Solvable reports whether there is any assignment of operators which yields the goal of the
+// equation, stopping at the first one found. If none is found but any intermediate value
+// doesn't fit in an int, ops.ErrOverflow is returned as the result is not reliable.
+func (s Solver) Solvable(e Equation) (found bool, err error) {
+	err = s.search(e, func(Assignment) bool {
+		found = true
+		return false
+	})
+	if found {
+		err = nil
+	}
+	return
+}
+
+// search goes backwards from the goal: the last operator must turn some value into the goal
+// along with the last number, so undoing it (see ops.Op.Inverse) gives the goal of the equation
+// without the last number. This prunes most of the branches early, e.g. multiplication only when
+// the goal is divisible by the number, or concatenation when the goal ends with its digits.
+// Operators which can't be undone fall back to evaluating every assignment of the remaining
+// numbers. yield is called with every assignment found, until it returns false. Branches which
+// overflow are skipped, reporting ops.ErrOverflow once the search is done.
+func (s Solver) search(e Equation, yield func(Assignment) bool) error {
+	if len(e.Numbers) == 0 {
+		return nil
+	}
+	// Without subtractions nor divisions, positive numbers never yield a negative value
+	monotone := true
+	for _, op := range s.Ops {
+		monotone = monotone && (op == ops.ADD || op == ops.MUL || op == ops.CONCAT)
+	}
+	for _, n := range e.Numbers {
+		monotone = monotone && n >= 0
+	}
+	var overflow bool
+	// apply checks the operation, noting whether it overflowed
+	apply := func(op ops.Op, a, b int) (int, bool) {
+		result, err := op.Apply(a, b)
+		overflow = overflow || errors.Is(err, ops.ErrOverflow)
+		return result, err == nil
+	}
+	assignment := make(Assignment, len(e.Numbers)-1)
+	var backward func(i, goal int) bool
+	backward = func(i, goal int) bool {
+		if i == 0 {
+			return e.Numbers[0] != goal || yield(assignment)
+		}
+		if monotone && goal < 0 {
+			return true
+		}
+		b := e.Numbers[i]
+		for _, op := range s.Ops {
+			assignment[i-1] = op
+			candidates, err := op.Inverse(goal, b)
+			overflow = overflow || errors.Is(err, ops.ErrOverflow)
+			if errors.Is(err, ops.ErrNoInverse) {
+				cont := s.forward(e.Numbers[:i], assignment, apply, func(value int) bool {
+					if result, ok := apply(op, value, b); ok && result == goal {
+						return yield(assignment)
+					}
+					return true
+				})
+				if !cont {
+					return false
+				}
+				continue
+			}
+			for _, a := range candidates {
+				if result, ok := apply(op, a, b); !ok || result != goal {
+					continue
+				}
+				if !backward(i-1, a) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	backward(len(e.Numbers)-1, e.Goal)
+	if overflow {
+		return fmt.Errorf("solving %d: %w", e.Goal, ops.ErrOverflow)
+	}
+	return nil
+}
+
+// forward evaluates every assignment of operators of the numbers, written into the beginning
+// of assignment, and calls yield with each value until it returns false
+func (s Solver) forward(
+	numbers []int, assignment Assignment, apply func(ops.Op, int, int) (int, bool), yield func(int) bool,
+) bool {
+	var next func(i, value int) bool
+	next = func(i, value int) bool {
+		if i == len(numbers) {
+			return yield(value)
+		}
+		for _, op := range s.Ops {
+			result, ok := apply(op, value, numbers[i])
+			if !ok {
+				continue
+			}
+			assignment[i-1] = op
+			if !next(i+1, result) {
+				return false
+			}
+		}
+		return true
+	}
+	return next(1, numbers[0])
+}
@@ -0,0 +1,130 @@
+package solver
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Javinator9889/aoc-2024/2024/day07/ops"
+)
+
+func TestSolver_Solve(t *testing.T) {
+	tests := []struct {
+		name     string
+		ops      []ops.Op
+		equation Equation
+		want     []string
+	}{
+		{
+			name:     "single",
+			ops:      []ops.Op{ops.ADD, ops.MUL},
+			equation: Equation{Goal: 190, Numbers: []int{10, 19}},
+			want:     []string{"10 * 19"},
+		},
+		{
+			name:     "many",
+			ops:      []ops.Op{ops.ADD, ops.MUL},
+			equation: Equation{Goal: 3267, Numbers: []int{81, 40, 27}},
+			want:     []string{"81 * 40 + 27", "(81 + 40) * 27"},
+		},
+		{
+			name:     "none",
+			ops:      []ops.Op{ops.ADD, ops.MUL},
+			equation: Equation{Goal: 156, Numbers: []int{15, 6}},
+		},
+		{
+			name:     "concat",
+			ops:      []ops.Op{ops.ADD, ops.MUL, ops.CONCAT},
+			equation: Equation{Goal: 7290, Numbers: []int{6, 8, 6, 15}},
+			want:     []string{"(6 * 8) || 6 * 15"},
+		},
+		{
+			name:     "sub and div",
+			ops:      []ops.Op{ops.SUB, ops.DIV},
+			equation: Equation{Goal: 1, Numbers: []int{10, 5, 1}},
+			want:     []string{"10 / 5 - 1"},
+		},
+		{
+			name:     "truncated div",
+			ops:      []ops.Op{ops.ADD, ops.DIV},
+			equation: Equation{Goal: 3, Numbers: []int{7, 2}},
+			want:     []string{"7 / 2"},
+		},
+		{
+			name:     "negative",
+			ops:      []ops.Op{ops.SUB, ops.CONCAT},
+			equation: Equation{Goal: -35, Numbers: []int{2, 5, 5}},
+			want:     []string{"(2 - 5) || 5"},
+		},
+		{
+			name:     "mul by zero",
+			ops:      []ops.Op{ops.ADD, ops.MUL},
+			equation: Equation{Goal: 0, Numbers: []int{3, 4, 0}},
+			want:     []string{"(3 + 4) * 0", "3 * 4 * 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Solver{Ops: tt.ops}
+			solutions, err := s.Solve(tt.equation)
+			if err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+			var got []string
+			for _, a := range solutions {
+				got = append(got, tt.equation.Expression(a))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Solve() = %q, want %q", got, tt.want)
+			}
+			bigSolutions, err := s.SolveBig(tt.equation.Big())
+			if err != nil || !reflect.DeepEqual(bigSolutions, solutions) {
+				t.Errorf("SolveBig() = %v, %v, want %v", bigSolutions, err, solutions)
+			}
+			if solvable, _ := s.Solvable(tt.equation); solvable != (len(tt.want) > 0) {
+				t.Errorf("Solvable() = %v, want %v", solvable, len(tt.want) > 0)
+			}
+		})
+	}
+}
+
+func TestSolver_Solve_registered(t *testing.T) {
+	// Without an inverse, the solver evaluates every assignment before the operator
+	const MOD = ops.Op("mod")
+	err := ops.Register(MOD, ops.Operator{
+		Symbol:     "%",
+		Precedence: 2,
+		Apply: func(a, b int) (int, error) {
+			if b == 0 {
+				return 0, ops.ErrInvalid
+			}
+			return a % b, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	defer ops.Unregister(MOD)
+	equation := Equation{Goal: 1, Numbers: []int{7, 3, 3}}
+	solutions, err := Solver{Ops: []ops.Op{ops.ADD, MOD}}.Solve(equation)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	var got []string
+	for _, a := range solutions {
+		got = append(got, equation.Expression(a))
+	}
+	if want := []string{"(7 + 3) % 3", "7 % 3 % 3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Solve() = %q, want %q", got, want)
+	}
+}
+
+func TestSolver_overflow(t *testing.T) {
+	// (MaxInt + 2) * 0 == 0, but the sum doesn't fit
+	equation := Equation{Goal: 0, Numbers: []int{math.MaxInt, 2, 0}}
+	found, err := Solver{Ops: []ops.Op{ops.ADD, ops.MUL}}.Solvable(equation)
+	if found || !errors.Is(err, ops.ErrOverflow) {
+		t.Errorf("Solvable() = %v, %v, want error %v", found, err, ops.ErrOverflow)
+	}
+}
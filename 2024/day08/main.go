@@ -1,7 +1,6 @@
 package main
 
 import (
-	_ "embed"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -10,15 +9,11 @@ import (
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-//go:embed input.txt
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
@@ -26,7 +21,9 @@ func main() {
 	var debug bool
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
 	flag.BoolVar(&debug, "debug", false, "debug mode")
+	readInput := util.InputFlags(flag.CommandLine)
 	flag.Parse()
+	input = readInput()
 	fmt.Println("Running part", part)
 	if debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
@@ -50,7 +47,6 @@ const (
 type Loc struct {
 	frequency rune
 	x, y      int
-	antinode  bool
 }
 
 type Frequency rune
@@ -65,12 +61,9 @@ func (l Location) Direction(o Location) Vector {
 	return Vector{o.x - l.x, o.y - l.y}
 }
 
-// The reflection of a point simply takes the direction vector of the line between the two points
-// and adds it to the second point.
-func (l Location) Reflection(o Location) Location {
-	vd := l.Direction(o)
-	px, py := o.x+vd.x, o.y+vd.y
-	return Location{px, py}
+// Move returns the location n steps away in the given direction
+func (l Location) Move(v Vector, n int) Location {
+	return Location{l.x + v.x*n, l.y + v.y*n}
 }
 
 type Antennas map[Frequency][]Location
@@ -81,102 +74,122 @@ func (g Grid) InBounds(l Location) bool {
 	return l.x >= 0 && l.x < len(g) && l.y >= 0 && l.y < len(g[0])
 }
 
-func part1(input string) (antinodes int) {
-	grid, antennas := parseInput(input)
-	// Calculate the antinode of each frequency. The antinode is defined as the point in the
-	// grid that is aligned with the antennas of the same frequency. The antennas must be aligned
-	// and be twice as far away as the other.
+// ALL_HARMONICS makes the antinodes reach the border of the grid, see Mode
+const ALL_HARMONICS = -1
+
+// A Mode tells where the antinodes of each pair of antennas are. The n-th harmonic is n times the
+// distance between the antennas away from them, on both sides of the line that joins them: the
+// antennas themselves are the harmonic 0.
+type Mode struct {
+	Min, Max int // The harmonics with antinodes, Max may be ALL_HARMONICS
+	// Reduced places the antinodes in every grid position along the line, not only at whole
+	// harmonics. The positions between the antennas count as the harmonic 0.
+	Reduced bool
+}
+
+var (
+	EXACT     = Mode{Min: 1, Max: 1}             // Twice as far from one antenna as the other
+	HARMONICS = Mode{Min: 0, Max: ALL_HARMONICS} // Every harmonic, including the antennas
+)
+
+// FirstHarmonics returns the mode with the first n harmonics, excluding the antennas
+func FirstHarmonics(n int) Mode {
+	return Mode{Min: 1, Max: n}
+}
+
+// Antinodes returns the locations of the antinodes within the grid, of every pair of antennas
+// with the same frequency
+func Antinodes(grid Grid, antennas Antennas, mode Mode) map[Location]bool {
+	antinodes := map[Location]bool{}
 	for freq, locs := range antennas {
-		if len(locs) < 2 {
-			continue
+		for i := range locs {
+			for j := i + 1; j < len(locs); j++ {
+				slog.Debug("Pair", "freq", string(freq), "a", locs[i], "b", locs[j])
+				pairAntinodes(grid, locs[i], locs[j], mode, antinodes)
+			}
+		}
+	}
+	return antinodes
+}
+
+// pairAntinodes adds the antinodes of a pair of antennas. The line between them is walked in
+// steps of the direction reduced by the GCD of its coordinates, so there are g steps between the
+// antennas. Only every g-th step is a whole harmonic.
+func pairAntinodes(grid Grid, a, b Location, mode Mode, antinodes map[Location]bool) {
+	d := a.Direction(b)
+	g := gcd(abs(d.x), abs(d.y))
+	step := Vector{d.x / g, d.y / g}
+	unit := g
+	if mode.Reduced {
+		unit = 1
+	}
+	if mode.Min == 0 {
+		for k := 0; k <= g; k += unit {
+			antinodes[a.Move(step, k)] = true
+		}
+	}
+	// Walk away from each antenna, starting at the first harmonic not added yet
+	start := max(mode.Min*g, unit)
+	for _, from := range []struct {
+		loc Location
+		dir Vector
+	}{{b, step}, {a, Vector{-step.x, -step.y}}} {
+		for k := start; mode.Max == ALL_HARMONICS || k <= mode.Max*g; k += unit {
+			antinode := from.loc.Move(from.dir, k)
+			if !grid.InBounds(antinode) {
+				break
+			}
+			antinodes[antinode] = true
 		}
-		// Calculate the reflection of the antennas
-		for a := range locs {
-			for b := range locs {
-				if a == b {
-					continue
-				}
-				reflection := locs[a].Reflection(locs[b])
-				if grid.InBounds(reflection) {
-					slog.Debug(
-						"Reflection",
-						"freq", string(freq),
-						"a", locs[a],
-						"b", locs[b],
-						"reflection", reflection,
-					)
-					if !grid[reflection.x][reflection.y].antinode {
-						grid[reflection.x][reflection.y].antinode = true
-						antinodes++
-					}
-				} else {
-					slog.Debug(
-						"Reflection out of bounds",
-						"freq", string(freq),
-						"a", locs[a],
-						"b", locs[b],
-						"reflection", reflection,
-					)
-				}
+	}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Render draws the grid with a `#` on every antinode, unless there is an antenna on it
+func (g Grid) Render(antinodes map[Location]bool) string {
+	var sb strings.Builder
+	for x, row := range g {
+		for y, loc := range row {
+			if loc.frequency == OPEN && antinodes[Location{x, y}] {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteRune(loc.frequency)
 			}
 		}
+		if x < len(g)-1 {
+			sb.WriteByte('\n')
+		}
 	}
+	return sb.String()
+}
 
-	return
+func part1(input string) int {
+	grid, antennas := parseInput(input)
+	// Calculate the antinode of each frequency. The antinode is defined as the point in the
+	// grid that is aligned with the antennas of the same frequency. The antennas must be aligned
+	// and be twice as far away as the other.
+	return len(Antinodes(grid, antennas, EXACT))
 }
 
-func part2(input string) (antinodes int) {
+func part2(input string) int {
 	grid, antennas := parseInput(input)
 	// For the second part, we have to take into account the resonant harmonics. This simply means
 	// the antennas emit in a straight line to any grid position, needed at least two antennas of
 	// the same frequency aligned. The antinodes are now located all along the line within the grid
-	for freq, locs := range antennas {
-		if len(locs) < 2 {
-			continue
-		}
-		// Calculate the reflection of the antennas
-		for a := range locs {
-			for b := range locs {
-				if a == b {
-					loc := locs[a]
-					if !grid[loc.x][loc.y].antinode {
-						grid[loc.x][loc.y].antinode = true
-						antinodes++
-					}
-					continue
-				}
-				prev := locs[a]
-				point := locs[b]
-				for {
-					reflection := prev.Reflection(point)
-					if !grid.InBounds(reflection) {
-						slog.Debug(
-							"Reflection out of bounds",
-							"freq", string(freq),
-							"a", prev,
-							"b", point,
-							"reflection", reflection,
-						)
-						break
-					}
-					slog.Debug(
-						"Reflection",
-						"freq", string(freq),
-						"a", prev,
-						"b", point,
-						"reflection", reflection,
-					)
-					if !grid[reflection.x][reflection.y].antinode {
-						grid[reflection.x][reflection.y].antinode = true
-						antinodes++
-					}
-					prev = point
-					point = reflection
-				}
-			}
-		}
-	}
-	return
+	return len(Antinodes(grid, antennas, HARMONICS))
 }
 
 func parseInput(input string) (Grid, Antennas) {
@@ -3,6 +3,8 @@ package main
 import (
 	"log/slog"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `............
@@ -38,6 +40,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -65,9 +70,62 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestAntinodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		mode  Mode
+		want  int
+	}{
+		{"exact", example, EXACT, 14},
+		{"first harmonic", example, FirstHarmonics(1), 14},
+		{"first 2 harmonics", example, FirstHarmonics(2), 20},
+		{"all harmonics", example, HARMONICS, 34},
+		{"reduced", example, Mode{Min: 0, Max: ALL_HARMONICS, Reduced: true}, 34},
+		{"between", "A....\n.....\n....A", Mode{Min: 0, Max: 0, Reduced: true}, 3},
+		{"between whole", "A....\n.....\n....A", Mode{Min: 0, Max: 0}, 2},
+		{"reduced first", "A....\n.....\n..A..\n.....\n.....", Mode{Min: 0, Max: 1, Reduced: true}, 5},
+		{"whole first", "A....\n.....\n..A..\n.....\n.....", Mode{Min: 0, Max: 1}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, antennas := parseInput(tt.input)
+			if got := Antinodes(grid, antennas, tt.mode); len(got) != tt.want {
+				t.Errorf("Antinodes() = %v, want %v antinodes", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrid_Render(t *testing.T) {
+	grid, antennas := parseInput(example)
+	want := `......#....#
+...#....0...
+....#0....#.
+..#....0....
+....0....#..
+.#....A.....
+...#........
+#......#....
+........A...
+.........A..
+..........#.
+..........#.`
+	if got := grid.Render(Antinodes(grid, antennas, EXACT)); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
@@ -0,0 +1,310 @@
+package block
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// FREE is the ID of the spans of free space
+const FREE = -1
+
+// A Span is a run of contiguous blocks of the disk, holding (part of) a file or free space
+type Span struct {
+	ID     int // The ID of the file, or FREE
+	Start  int
+	Length int
+}
+
+// End returns the position right after the last block of the span
+func (s Span) End() int {
+	return s.Start + s.Length
+}
+
+// Layout is the disk as a list of spans, sorted by their start and without gaps between them.
+// Files may be empty, as the disk map allows them, so their ID isn't given to the next file.
+type Layout []Span
+
+// ParseLayout reads the dense disk map, whose digits alternate between the length of a file and
+// the length of the free space after it. Files are numbered in order, starting at 0.
+func ParseLayout(diskMap string) (Layout, error) {
+	var layout Layout
+	start := 0
+	for i, c := range diskMap {
+		if c == '\n' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid length %q at %d", c, i)
+		}
+		length := int(c - '0')
+		id := FREE
+		if i%2 == 0 {
+			id = i / 2
+		}
+		if length > 0 || id != FREE {
+			layout = append(layout, Span{ID: id, Start: start, Length: length})
+		}
+		start += length
+	}
+	return layout.normalize(), nil
+}
+
+// Size returns the number of blocks of the disk
+func (l Layout) Size() int {
+	if len(l) == 0 {
+		return 0
+	}
+	return l[len(l)-1].End()
+}
+
+// Chksum returns the sum of the position of each block times the ID of its file
+func (l Layout) Chksum() (chksum int) {
+	for _, s := range l {
+		if s.ID == FREE {
+			continue
+		}
+		// The sum of the positions from Start to End-1
+		chksum += s.ID * (s.Start + s.End() - 1) * s.Length / 2
+	}
+	return
+}
+
+// A Move of blocks of a file to free space
+type Move struct {
+	ID       int
+	From, To int // The first block moved, and where it's moved to
+	Length   int
+}
+
+// A Compactor moves the files of a layout towards the free space at the beginning of the disk,
+// starting with the last file. Each move made is passed to onMove, if not nil. Empty files have
+// no blocks to move, so they are left out of the compacted layout.
+type Compactor interface {
+	Compact(l Layout, onMove func(Move)) Layout
+}
+
+// Fragmenting moves the blocks of each file one at a time to the leftmost free block, splitting
+// the files across the free spans
+type Fragmenting struct{}
+
+// WholeFile moves each file, once, to the leftmost free span where it fits
+type WholeFile struct{}
+
+func (Fragmenting) Compact(l Layout, onMove func(Move)) Layout {
+	free := newFreeIndex(l)
+	var placed []Span
+	files := l.files()
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		for file.Length > 0 {
+			// The free space must be before the last block of the file
+			span, ok := free.take(1, file.End()-1)
+			if !ok {
+				break
+			}
+			n := min(span.Length, file.Length)
+			placed = append(placed, Span{ID: file.ID, Start: span.Start, Length: n})
+			free.put(Span{ID: FREE, Start: span.Start + n, Length: span.Length - n})
+			file.Length -= n
+			if onMove != nil {
+				onMove(Move{ID: file.ID, From: file.End(), To: span.Start, Length: n})
+			}
+		}
+		if file.Length > 0 {
+			placed = append(placed, file)
+		}
+	}
+	return fill(placed, l.Size())
+}
+
+func (WholeFile) Compact(l Layout, onMove func(Move)) Layout {
+	free := newFreeIndex(l)
+	var placed []Span
+	files := l.files()
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		if span, ok := free.take(file.Length, file.Start); ok {
+			if onMove != nil {
+				onMove(Move{ID: file.ID, From: file.Start, To: span.Start, Length: file.Length})
+			}
+			file.Start = span.Start
+			free.put(Span{ID: FREE, Start: span.Start + file.Length, Length: span.Length - file.Length})
+		}
+		placed = append(placed, file)
+	}
+	return fill(placed, l.Size())
+}
+
+// Apply makes the move on a copy of the layout. The blocks moved must be of the file, and the
+// blocks they are moved to must be free.
+func (l Layout) Apply(m Move) (Layout, error) {
+	blocks := make([]int, l.Size())
+	for _, s := range l {
+		for i := s.Start; i < s.End(); i++ {
+			blocks[i] = s.ID
+		}
+	}
+	if m.From < 0 || m.To < 0 || m.From+m.Length > len(blocks) || m.To+m.Length > len(blocks) {
+		return nil, fmt.Errorf("move %+v out of the disk", m)
+	}
+	for i := 0; i < m.Length; i++ {
+		if blocks[m.From+i] != m.ID || blocks[m.To+i] != FREE {
+			return nil, fmt.Errorf("invalid move %+v at block %d", m, i)
+		}
+		blocks[m.From+i], blocks[m.To+i] = FREE, m.ID
+	}
+	var moved Layout
+	for i, id := range blocks {
+		moved = append(moved, Span{ID: id, Start: i, Length: 1})
+	}
+	return moved.normalize(), nil
+}
+
+// A Stepper goes through the compaction of a layout one move at a time, for debugging
+type Stepper struct {
+	layout Layout
+	moves  []Move
+}
+
+// NewStepper compacts the layout, keeping every move to replay them with Step
+func NewStepper(l Layout, c Compactor) *Stepper {
+	s := &Stepper{layout: l}
+	c.Compact(l, func(m Move) { s.moves = append(s.moves, m) })
+	return s
+}
+
+// Step makes the next move, returning false when the compaction is finished
+func (s *Stepper) Step() (Move, bool) {
+	if len(s.moves) == 0 {
+		return Move{}, false
+	}
+	m := s.moves[0]
+	layout, err := s.layout.Apply(m)
+	if err != nil {
+		// The moves come from the compactor, so they must be valid
+		panic(err)
+	}
+	s.layout, s.moves = layout, s.moves[1:]
+	return m, true
+}
+
+// Layout returns the layout after the moves made so far
+func (s *Stepper) Layout() Layout {
+	return s.layout
+}
+
+// Left returns the number of moves left
+func (s *Stepper) Left() int {
+	return len(s.moves)
+}
+
+// normalize merges the contiguous spans of the same file, or of free space
+func (l Layout) normalize() (merged Layout) {
+	for _, s := range l {
+		if len(merged) > 0 && merged[len(merged)-1].ID == s.ID && merged[len(merged)-1].End() == s.Start {
+			merged[len(merged)-1].Length += s.Length
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return
+}
+
+// files returns the spans with files, merging the contiguous ones of the same file and skipping
+// the empty ones
+func (l Layout) files() (files []Span) {
+	for _, s := range l {
+		switch {
+		case s.ID == FREE || s.Length == 0:
+		case len(files) > 0 && files[len(files)-1].ID == s.ID && files[len(files)-1].End() == s.Start:
+			files[len(files)-1].Length += s.Length
+		default:
+			files = append(files, s)
+		}
+	}
+	return
+}
+
+// fill sorts the spans with files and adds the free spans between them, up to size
+func fill(files []Span, size int) (layout Layout) {
+	sort.Slice(files, func(i, j int) bool { return files[i].Start < files[j].Start })
+	pos := 0
+	for _, f := range files {
+		if f.Start > pos {
+			layout = append(layout, Span{ID: FREE, Start: pos, Length: f.Start - pos})
+		}
+		layout = append(layout, f)
+		pos = f.End()
+	}
+	if size > pos {
+		layout = append(layout, Span{ID: FREE, Start: pos, Length: size - pos})
+	}
+	return layout.normalize()
+}
+
+// freeIndex keeps the free spans in a min-heap of their starts for each length, so the leftmost
+// free span with room for a file is found without going through the whole disk
+type freeIndex []starts
+
+func newFreeIndex(l Layout) freeIndex {
+	// The free spans around empty files are contiguous
+	var spans []Span
+	longest := 0
+	for _, s := range l {
+		if s.ID != FREE {
+			continue
+		}
+		if len(spans) > 0 && spans[len(spans)-1].End() == s.Start {
+			spans[len(spans)-1].Length += s.Length
+		} else {
+			spans = append(spans, s)
+		}
+		longest = max(longest, spans[len(spans)-1].Length)
+	}
+	free := make(freeIndex, longest+1)
+	for _, s := range spans {
+		free.put(s)
+	}
+	return free
+}
+
+func (f freeIndex) put(s Span) {
+	if s.Length > 0 {
+		heap.Push(&f[s.Length], s.Start)
+	}
+}
+
+// take removes the leftmost free span of at least the given length starting before the given
+// position, if any
+func (f freeIndex) take(length, before int) (Span, bool) {
+	best := -1
+	for size := max(length, 1); size < len(f); size++ {
+		if len(f[size]) > 0 && f[size][0] < before && (best == -1 || f[size][0] < f[best][0]) {
+			best = size
+		}
+	}
+	if best == -1 {
+		return Span{}, false
+	}
+	return Span{ID: FREE, Start: heap.Pop(&f[best]).(int), Length: best}, true
+}
+
+// starts is a min-heap of positions
+type starts []int
+
+func (s starts) Len() int           { return len(s) }
+func (s starts) Less(i, j int) bool { return s[i] < s[j] }
+func (s starts) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (s *starts) Push(x interface{}) {
+	*s = append(*s, x.(int))
+}
+
+func (s *starts) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	*s = old[:n-1]
+	return x
+}
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompactor(t *testing.T) {
+	tests := []struct {
+		name      string
+		compactor Compactor
+		input     string
+		want      Layout
+	}{
+		{
+			name:      "fragmenting",
+			compactor: Fragmenting{},
+			input:     "12345",
+			want: Layout{
+				{ID: 0, Start: 0, Length: 1},
+				{ID: 2, Start: 1, Length: 2},
+				{ID: 1, Start: 3, Length: 3},
+				{ID: 2, Start: 6, Length: 3},
+				{ID: FREE, Start: 9, Length: 6},
+			},
+		},
+		{
+			name:      "whole file",
+			compactor: WholeFile{},
+			input:     "12345",
+			want: Layout{
+				{ID: 0, Start: 0, Length: 1},
+				{ID: FREE, Start: 1, Length: 2},
+				{ID: 1, Start: 3, Length: 3},
+				{ID: FREE, Start: 6, Length: 4},
+				{ID: 2, Start: 10, Length: 5},
+			},
+		},
+		{
+			name:      "whole file across an empty file",
+			compactor: WholeFile{},
+			input:     "12034",
+			want: Layout{
+				{ID: 0, Start: 0, Length: 1},
+				{ID: 2, Start: 1, Length: 4},
+				{ID: FREE, Start: 5, Length: 5},
+			},
+		},
+		{
+			name:      "fragmenting across an empty file",
+			compactor: Fragmenting{},
+			input:     "12034",
+			want: Layout{
+				{ID: 0, Start: 0, Length: 1},
+				{ID: 2, Start: 1, Length: 4},
+				{ID: FREE, Start: 5, Length: 5},
+			},
+		},
+		{
+			name:      "whole file moves",
+			compactor: WholeFile{},
+			input:     "1313",
+			want: Layout{
+				{ID: 0, Start: 0, Length: 1},
+				{ID: 1, Start: 1, Length: 1},
+				{ID: FREE, Start: 2, Length: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := ParseLayout(tt.input)
+			if err != nil {
+				t.Fatalf("ParseLayout() error = %v", err)
+			}
+			if got := tt.compactor.Compact(layout, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepper(t *testing.T) {
+	tests := []struct {
+		name      string
+		compactor Compactor
+		moves     int
+	}{
+		{"fragmenting", Fragmenting{}, 7},
+		{"whole file", WholeFile{}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := mustParseLayout(t, example)
+			stepper := NewStepper(layout, tt.compactor)
+			if stepper.Left() != tt.moves {
+				t.Errorf("Left() = %v, want %v", stepper.Left(), tt.moves)
+			}
+			for _, ok := stepper.Step(); ok; _, ok = stepper.Step() {
+			}
+			if got, want := stepper.Layout(), tt.compactor.Compact(layout, nil); !reflect.DeepEqual(got, want) {
+				t.Errorf("Layout() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package block
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BASE is the base the IDs are written in, so they take a single character up to BASE-1
+const BASE = 36
+
+// String renders one character per block: the ID of its file in base 36, or '.' if it's free.
+// Layouts with larger IDs are rendered span by span instead, e.g. "[40x2][.x3]".
+func (l Layout) String() string {
+	var sb strings.Builder
+	wide := false
+	for _, s := range l {
+		wide = wide || s.ID >= BASE
+	}
+	for _, s := range l {
+		switch {
+		case s.Length == 0:
+		case wide && s.ID == FREE:
+			fmt.Fprintf(&sb, "[.x%d]", s.Length)
+		case wide:
+			fmt.Fprintf(&sb, "[%dx%d]", s.ID, s.Length)
+		default:
+			sb.WriteString(strings.Repeat(string(idChar(s.ID)), s.Length))
+		}
+	}
+	return sb.String()
+}
+
+// Colored renders one character per block as String does, wrapping the IDs above 35 around.
+// Each file is colored with ANSI escape codes after its ID divided by 36, so files with the
+// same character can be told apart (up to 36 * 216 files).
+func (l Layout) Colored() string {
+	var sb strings.Builder
+	for _, s := range l {
+		if s.Length == 0 {
+			continue
+		}
+		if s.ID == FREE {
+			sb.WriteString(strings.Repeat(".", s.Length))
+			continue
+		}
+		// The 6x6x6 color cube of the 256 colors palette, starting from white as the darkest
+		// colors are hard to read
+		color := 231 - (s.ID/BASE)%216
+		fmt.Fprintf(&sb, "\x1b[38;5;%dm%s\x1b[0m", color, strings.Repeat(string(idChar(s.ID)), s.Length))
+	}
+	return sb.String()
+}
+
+// idChar returns the last digit of the ID in base 36, or '.' if free
+func idChar(id int) byte {
+	if id == FREE {
+		return '.'
+	}
+	return strconv.FormatInt(int64(id%BASE), BASE)[0]
+}
+
+// DiskMap encodes the layout back into the dense disk map (see ParseLayout). Only layouts with
+// the files in order of ID, each in a single span of up to 9 blocks, can be encoded; free spans
+// can be of any length up to 9, once merged. Empty files are kept, so parsing a disk map and
+// encoding it gives it back.
+func (l Layout) DiskMap() (string, error) {
+	var sb strings.Builder
+	next, free := 0, 0
+	for _, s := range l.normalize() {
+		if s.ID == FREE {
+			free += s.Length
+			continue
+		}
+		if s.ID != next {
+			return "", fmt.Errorf("file %d at %d is out of order, expected file %d", s.ID, s.Start, next)
+		}
+		if s.Length > 9 {
+			return "", fmt.Errorf("file %d is too long: %d blocks", s.ID, s.Length)
+		}
+		if next > 0 {
+			if free > 9 {
+				return "", fmt.Errorf("free space before file %d is too long: %d blocks", s.ID, free)
+			}
+			sb.WriteByte(byte('0' + free))
+		} else if free > 0 {
+			return "", fmt.Errorf("the disk must start with a file")
+		}
+		sb.WriteByte(byte('0' + s.Length))
+		next, free = next+1, 0
+	}
+	if free > 9 {
+		return "", fmt.Errorf("free space at the end is too long: %d blocks", free)
+	}
+	if free > 0 {
+		sb.WriteByte(byte('0' + free))
+	}
+	return sb.String(), nil
+}
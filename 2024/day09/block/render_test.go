@@ -0,0 +1,78 @@
+package block
+
+import "testing"
+
+const example = `2333133121414131402`
+
+func TestLayout_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		want   string
+	}{
+		{
+			name:   "digits",
+			layout: mustParseLayout(t, example),
+			want:   "00...111...2...333.44.5555.6666.777.888899",
+		},
+		{
+			name:   "base 36",
+			layout: Layout{{ID: 10, Start: 0, Length: 2}, {ID: FREE, Start: 2, Length: 1}, {ID: 35, Start: 3, Length: 1}},
+			want:   "aa.z",
+		},
+		{
+			name:   "wide",
+			layout: Layout{{ID: 40, Start: 0, Length: 2}, {ID: FREE, Start: 2, Length: 3}, {ID: 4, Start: 5, Length: 1}},
+			want:   "[40x2][.x3][4x1]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.layout.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayout_DiskMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  Layout
+		want    string
+		wantErr bool
+	}{
+		{"example", mustParseLayout(t, example), example, false},
+		{"trailing free space", mustParseLayout(t, "12345"), "12345", false},
+		{"empty file", mustParseLayout(t, "10003"), "10003", false},
+		{"empty file before free space", mustParseLayout(t, "1203"), "1203", false},
+		{"empty free space", mustParseLayout(t, "10203"), "10203", false},
+		{"compacted", WholeFile{}.Compact(mustParseLayout(t, "1313"), nil), "1016", false},
+		{"out of order", WholeFile{}.Compact(mustParseLayout(t, example), nil), "", true},
+		{"fragmented", Fragmenting{}.Compact(mustParseLayout(t, "12345"), nil), "", true},
+		{
+			name:    "long free space",
+			layout:  Layout{{ID: 0, Start: 0, Length: 1}, {ID: FREE, Start: 1, Length: 10}, {ID: 1, Start: 11, Length: 1}},
+			wantErr: true,
+		},
+		{"long file", Layout{{ID: 0, Start: 0, Length: 10}}, "", true},
+		{"starting with free space", Layout{{ID: FREE, Start: 0, Length: 1}, {ID: 0, Start: 1, Length: 1}}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.layout.DiskMap()
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("DiskMap() = %q, %v, want %q (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustParseLayout(t *testing.T, diskMap string) Layout {
+	t.Helper()
+	layout, err := ParseLayout(diskMap)
+	if err != nil {
+		t.Fatalf("ParseLayout() error = %v", err)
+	}
+	return layout
+}
@@ -2,7 +2,12 @@ package main
 
 import (
 	"log/slog"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/2024/day09/block"
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `2333133121414131402`
@@ -27,6 +32,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -54,9 +62,301 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+// A File of the first implementation, which is placed in blocks
+type File struct {
+	ID     int
+	Length int
+}
+
+func (f File) String() string {
+	return strings.Repeat(strconv.Itoa(f.ID), f.Length)
+}
+
+// A Block is a file of the disk map followed by its free space, or just free space. Moved files
+// are added to the free space of the blocks.
+type Block struct {
+	MaxSize int
+	files   []*File
+}
+
+func (b Block) Length() (length int) {
+	for _, f := range b.files {
+		length += f.Length
+	}
+	return
+}
+
+func (b Block) Free() int {
+	return b.MaxSize - b.Length()
+}
+
+// Adds a file, increasing the block's size
+func (b *Block) Push(file *File) int {
+	b.files = append(b.files, file)
+	b.MaxSize += file.Length
+	return file.Length
+}
+
+func (b *Block) Add(file *File) bool {
+	if b.Free() < file.Length {
+		return false
+	}
+	b.files = append(b.files, file)
+	return true
+}
+
+func (b *Block) AddPartial(file *File) bool {
+	free := b.Free()
+	if free == 0 {
+		return false
+	}
+	needle := min(free, file.Length)
+	// Fast path: The file fits in the block
+	if needle == file.Length {
+		b.files = append(b.files, file)
+		return true
+	}
+	partial := &File{ID: file.ID, Length: needle}
+	b.files = append(b.files, partial)
+	file.Length -= needle
+	return false
+}
+
+func (b *Block) PopLast() *File {
+	if len(b.files) == 0 {
+		return nil
+	}
+	file := b.files[len(b.files)-1]
+	b.files = b.files[:len(b.files)-1]
+	return file
+}
+
+func (b Block) Chksum(offset *int) (chksum int) {
+	base := *offset
+	for _, f := range b.files {
+		for i := 0; i < f.Length; i++ {
+			chksum += f.ID * base
+			base++
+		}
+	}
+	// The offset is increased by the block's size
+	*offset += b.MaxSize
+	return
+}
+
+func (b Block) String() string {
+	var sb strings.Builder
+	for _, f := range b.files {
+		sb.WriteString(f.String())
+	}
+	sb.WriteString(strings.Repeat(".", b.Free()))
+	return sb.String()
+}
+
+type Disk []*Block
+
+func (d Disk) FreeBlock(minSize int) (int, *Block) {
+	for i, b := range d {
+		if b.Free() >= minSize {
+			return i, b
+		}
+	}
+	return -1, nil
+}
+
+func (d Disk) String() string {
+	var sb strings.Builder
+	for _, b := range d {
+		sb.WriteString(b.String())
+	}
+	return sb.String()
+}
+
+// parseDisk reads the disk map into blocks for the first implementation
+func parseDisk(input string) Disk {
+	disk := make(Disk, 0)
+	idx := 0
+	// The input is a single line with a series of numbers
+	for i, c := range input {
+		if c == '\n' || c == '0' { // Skip newlines and zeroes
+			continue
+		}
+		blk := &Block{}
+		length := int(c - '0')
+		if i%2 == 0 {
+			blk.Push(&File{ID: idx, Length: length})
+			idx++
+		} else {
+			blk.MaxSize = length
+		}
+		disk = append(disk, blk)
+	}
+	return disk
+}
+
+// diskFragmentingImpl compacts the disk block by block, looking for free space from the start
+// of the disk every time
+func diskFragmentingImpl(input string) (chksum int) {
+	disk := parseDisk(input)
+	slog.Debug("Disk layout", "disk", disk)
+	// Reallocate the blocks, starting from the end. As we're moving the files from the end,
+	// we can just reallocate until the middle of the disk.
+outer:
+	for i := len(disk) - 1; i >= 0; i-- {
+		blk := disk[i]
+		if blk.Free() == blk.MaxSize {
+			// Skip empty blocks
+			continue
+		}
+		// Reallocate the block
+		freeIdx, freeBlk := disk.FreeBlock(1)
+		slog.Debug("Reallocating", "block", blk)
+		for f := blk.PopLast(); f != nil; {
+			if freeIdx == -1 {
+				blk.Add(f)
+				slog.Debug("No free blocks left")
+				break outer
+			}
+			// Do not move a block after its position
+			if freeIdx >= i {
+				blk.Add(f)
+				slog.Debug("No free blocks after current block", "block", i)
+				break outer
+			}
+			if freeBlk.AddPartial(f) {
+				break
+			}
+			freeIdx, freeBlk = disk.FreeBlock(1)
+		}
+		slog.Debug("Disk after reallocating", "i", i, "disk", disk)
+	}
+	slog.Debug("Final disk", "disk", disk)
+
+	// Compute the new checksum
+	offset := 0
+	for _, blk := range disk {
+		chksum += blk.Chksum(&offset)
+	}
+	return
+}
+
+// diskWholeFileImpl is the same as diskFragmentingImpl, moving whole files
+func diskWholeFileImpl(input string) (chksum int) {
+	// Part two is the same as part one, but we need to find enough room for a whole file to fit
+	// on a free block.
+	disk := parseDisk(input)
+	slog.Debug("Disk layout", "disk", disk)
+	for i := len(disk) - 1; i >= 0; i-- {
+		blk := disk[i]
+		if blk.Free() == blk.MaxSize {
+			// Skip empty blocks
+			continue
+		}
+		freeIdx, freeBlk := disk.FreeBlock(blk.Length())
+		if freeIdx == -1 || freeIdx >= i {
+			// No free blocks left or no blocks after the current one
+			slog.Debug("No room for re-arranging block", "block", blk)
+			continue
+		}
+		// Reallocate the block
+		slog.Debug("Reallocating", "block", blk)
+		for {
+			var f *File
+			if f = blk.PopLast(); f == nil {
+				break
+			}
+			// Add the file to the free block
+			if !freeBlk.Add(f) {
+				// The file should fit, there's an error
+				slog.Error("File does not fit in free block", "file", f, "block", freeBlk)
+				panic("File does not fit in free block")
+			}
+			// Move to the next free block
+			freeIdx, freeBlk = disk.FreeBlock(blk.Length())
+			if freeIdx == -1 || freeIdx >= i {
+				// No free blocks left or no blocks after the current one
+				break
+			}
+		}
+		slog.Debug("Disk after reallocating", "i", i, "disk", disk)
+	}
+	slog.Debug("Final disk", "disk", disk)
+
+	// Compute the new checksum
+	offset := 0
+	for _, blk := range disk {
+		chksum += blk.Chksum(&offset)
+	}
+	return
+}
+
+func Test_diskImpl(t *testing.T) {
+	if got := diskFragmentingImpl(example); got != 1928 {
+		t.Errorf("diskFragmentingImpl() = %v, want %v", got, 1928)
+	}
+	if got := diskWholeFileImpl(example); got != 2858 {
+		t.Errorf("diskWholeFileImpl() = %v, want %v", got, 2858)
+	}
+}
+
+func FuzzCompactor(f *testing.F) {
+	f.Add(example)
+	f.Add("12345")
+	f.Add("90909")
+	f.Fuzz(func(t *testing.T, input string) {
+		// The first implementation is quadratic, so keep the inputs short
+		if len(input) > 256 {
+			return
+		}
+		// The first implementation skips the IDs of empty files, so the files are never empty
+		var diskMap strings.Builder
+		for i, c := range []byte(input) {
+			if i%2 == 0 {
+				diskMap.WriteByte('1' + c%9)
+			} else {
+				diskMap.WriteByte('0' + c%10)
+			}
+		}
+		if diskMap.Len() == 0 {
+			return
+		}
+		if got, want := compact(diskMap.String(), block.Fragmenting{}), diskFragmentingImpl(diskMap.String()); got != want {
+			t.Errorf("Fragmenting %q = %v, want %v", diskMap.String(), got, want)
+		}
+		if got, want := compact(diskMap.String(), block.WholeFile{}), diskWholeFileImpl(diskMap.String()); got != want {
+			t.Errorf("WholeFile %q = %v, want %v", diskMap.String(), got, want)
+		}
+	})
+}
+
+func BenchmarkDisk(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
+	for i := 0; i < b.N; i++ {
+		diskWholeFileImpl(input)
+	}
+}
+
+func BenchmarkCompactor(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
+	for i := 0; i < b.N; i++ {
+		part2(input)
+	}
+}
+
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
@@ -1,25 +1,24 @@
 package main
 
 import (
-	_ "embed"
 	"flag"
 	"fmt"
+	"log"
 	"log/slog"
+	"math/bits"
 	"strings"
 
 	"github.com/Javinator9889/aoc-2024/cast"
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-//go:embed input.txt
 var input string
 
+var trailhead string
+
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
@@ -27,12 +26,20 @@ func main() {
 	var debug bool
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
 	flag.BoolVar(&debug, "debug", false, "debug mode")
+	readInput := util.InputFlags(flag.CommandLine)
+	flag.StringVar(&trailhead, "trailhead", "", "print every trail from the trailhead at \"row,column\"")
 	flag.Parse()
+	input = readInput()
 	fmt.Println("Running part", part)
 	if debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
 	}
 
+	if trailhead != "" {
+		printTrails(input, trailhead)
+		return
+	}
+
 	if part == 1 {
 		ans := part1(input)
 		util.CopyToClipboard(fmt.Sprintf("%v", ans))
@@ -44,9 +51,11 @@ func main() {
 	}
 }
 
+// IMPASSABLE is the height of the cells marked with '.', which are never part of a trail
+const IMPASSABLE = -1
+
 type Position struct {
-	height  int
-	visited map[Coordinate]struct{}
+	height int
 }
 
 type Grid [][]*Position
@@ -59,6 +68,10 @@ func (g Grid) String() string {
 	var sb strings.Builder
 	for _, row := range g {
 		for _, pos := range row {
+			if pos.height == IMPASSABLE {
+				sb.WriteString(".")
+				continue
+			}
 			sb.WriteString(fmt.Sprintf("%d", pos.height))
 		}
 		sb.WriteString("\n")
@@ -66,6 +79,21 @@ func (g Grid) String() string {
 	return sb.String()
 }
 
+// uphill returns the neighbours of c which are one height higher
+func (g Grid) uphill(c Coordinate) (next []Coordinate) {
+	height := g[c.i][c.j].height
+	if height == IMPASSABLE {
+		return nil
+	}
+	for _, d := range COORDS {
+		n := c.Add(d)
+		if !g.OutOfBounds(n) && g[n.i][n.j].height == height+1 {
+			next = append(next, n)
+		}
+	}
+	return
+}
+
 type Coordinate struct {
 	i, j int
 }
@@ -74,57 +102,164 @@ func (c Coordinate) Add(other Coordinate) Coordinate {
 	return Coordinate{c.i + other.i, c.j + other.j}
 }
 
+func (c Coordinate) String() string {
+	return fmt.Sprintf("(%d,%d)", c.i, c.j)
+}
+
 var UP = Coordinate{-1, 0}
 var DOWN = Coordinate{1, 0}
 var LEFT = Coordinate{0, -1}
 var RIGHT = Coordinate{0, 1}
 var COORDS = []Coordinate{UP, DOWN, LEFT, RIGHT}
 
-func Trailhead(from Coordinate, start Coordinate, grid Grid, countTotal bool) int {
-	current := grid[start.i][start.j]
-	if current.height == 9 {
-		if countTotal {
-			return 1
+// Trails holds, for every cell of a grid, the trails going up from it to a 9: Score is the number
+// of distinct 9s reachable and Rating the number of distinct trails
+type Trails struct {
+	Score  [][]int
+	Rating [][]int
+}
+
+// Evaluate fills the trails of every cell in one pass, from the 9s down to the 0s. A cell only
+// leads to its neighbours one height higher, whose trails are known by then: its rating is the
+// sum of theirs, and the 9s it reaches are the union of theirs, kept as bitsets.
+func Evaluate(grid Grid) Trails {
+	var byHeight [10][]Coordinate
+	peaks := make(map[Coordinate]int) // The index of each 9 in the bitsets
+	for i, row := range grid {
+		for j, pos := range row {
+			if pos.height < 0 || pos.height > 9 {
+				continue
+			}
+			c := Coordinate{i, j}
+			byHeight[pos.height] = append(byHeight[pos.height], c)
+			if pos.height == 9 {
+				peaks[c] = len(peaks)
+			}
 		}
-		if _, ok := current.visited[from]; !ok {
-			current.visited[from] = struct{}{}
-			return 1
+	}
+	words := (len(peaks) + 63) / 64
+	reached := make([][][]uint64, len(grid))
+	trails := Trails{Score: make([][]int, len(grid)), Rating: make([][]int, len(grid))}
+	for i, row := range grid {
+		reached[i] = make([][]uint64, len(row))
+		trails.Score[i] = make([]int, len(row))
+		trails.Rating[i] = make([]int, len(row))
+	}
+	for height := 9; height >= 0; height-- {
+		for _, c := range byHeight[height] {
+			set := make([]uint64, words)
+			if height == 9 {
+				set[peaks[c]/64] |= 1 << (peaks[c] % 64)
+				trails.Rating[c.i][c.j] = 1
+			}
+			for _, n := range grid.uphill(c) {
+				trails.Rating[c.i][c.j] += trails.Rating[n.i][n.j]
+				for w, word := range reached[n.i][n.j] {
+					set[w] |= word
+				}
+			}
+			for _, word := range set {
+				trails.Score[c.i][c.j] += bits.OnesCount64(word)
+			}
+			reached[c.i][c.j] = set
+		}
+		// The sets of the cells above aren't needed anymore
+		if height < 9 {
+			for _, c := range byHeight[height+1] {
+				reached[c.i][c.j] = nil
+			}
 		}
-		return 0
 	}
-	paths := make([]Coordinate, 0)
-	for _, c := range COORDS {
-		next := start.Add(c)
-		if grid.OutOfBounds(next) {
-			continue
+	return trails
+}
+
+// Trailheads returns the cells at height 0, in reading order
+func (g Grid) Trailheads() (heads []Coordinate) {
+	for i, row := range g {
+		for j, pos := range row {
+			if pos.height == 0 {
+				heads = append(heads, Coordinate{i, j})
+			}
 		}
-		if grid[next.i][next.j].height != current.height+1 {
-			continue
+	}
+	return
+}
+
+// A Trail is the path from a trailhead up to a 9, one height at a time
+type Trail []Coordinate
+
+func (t Trail) String() string {
+	steps := make([]string, len(t))
+	for i, c := range t {
+		steps[i] = c.String()
+	}
+	return strings.Join(steps, " -> ")
+}
+
+// Trails enumerates every trail starting at the given cell, trying the directions in the order of
+// COORDS. There are as many as the rating of the cell (see Evaluate).
+func (g Grid) Trails(from Coordinate) (trails []Trail) {
+	if g.OutOfBounds(from) || g[from.i][from.j].height == IMPASSABLE {
+		return nil
+	}
+	var walk func(path Trail)
+	walk = func(path Trail) {
+		last := path[len(path)-1]
+		if g[last.i][last.j].height == 9 {
+			trails = append(trails, append(Trail(nil), path...))
+			return
+		}
+		for _, next := range g.uphill(last) {
+			walk(append(path, next))
+		}
+	}
+	walk(Trail{from})
+	return
+}
+
+// Render draws the grid with only the heights along the trail
+func (g Grid) Render(t Trail) string {
+	on := make(map[Coordinate]bool, len(t))
+	for _, c := range t {
+		on[c] = true
+	}
+	var sb strings.Builder
+	for i, row := range g {
+		for j, pos := range row {
+			if on[Coordinate{i, j}] {
+				sb.WriteString(fmt.Sprintf("%d", pos.height))
+			} else {
+				sb.WriteString(".")
+			}
 		}
-		paths = append(paths, next)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func printTrails(input, trailhead string) {
+	var from Coordinate
+	if _, err := fmt.Sscanf(trailhead, "%d,%d", &from.i, &from.j); err != nil {
+		log.Fatalf("invalid trailhead %q, expected \"row,column\": %s", trailhead, err)
 	}
-	if len(paths) == 0 {
-		return 0
+	grid := parseInput(input)
+	if grid.OutOfBounds(from) || grid[from.i][from.j].height != 0 {
+		log.Fatalf("there is no trailhead at %s", from)
 	}
-	ans := 0
-	for _, p := range paths {
-		ans += Trailhead(from, p, grid, countTotal)
+	trails := grid.Trails(from)
+	fmt.Printf("%d trails from %s, reaching %d 9s\n", len(trails), from, Evaluate(grid).Score[from.i][from.j])
+	for _, t := range trails {
+		fmt.Println(t)
+		slog.Debug("trail\n" + grid.Render(t))
 	}
-	return ans
 }
 
 func part1(input string) (reachable int) {
 	parsed := parseInput(input)
 	slog.Debug("grid", "grid", parsed)
-	for i := range parsed {
-		for j := range parsed[i] {
-			pos := parsed[i][j]
-			if pos.height != 0 {
-				continue
-			}
-			from := Coordinate{i, j}
-			reachable += Trailhead(from, from, parsed, false)
-		}
+	trails := Evaluate(parsed)
+	for _, head := range parsed.Trailheads() {
+		reachable += trails.Score[head.i][head.j]
 	}
 
 	return
@@ -132,28 +267,22 @@ func part1(input string) (reachable int) {
 
 func part2(input string) (reachable int) {
 	parsed := parseInput(input)
-        slog.Debug("grid", "grid", parsed)
-        for i := range parsed {
-                for j := range parsed[i] {
-                        pos := parsed[i][j]
-                        if pos.height != 0 {
-                                continue
-                        }
-                        from := Coordinate{i, j}
-                        reachable += Trailhead(from, from, parsed, true)
-                }
-        }
+	slog.Debug("grid", "grid", parsed)
+	trails := Evaluate(parsed)
+	for _, head := range parsed.Trailheads() {
+		reachable += trails.Rating[head.i][head.j]
+	}
 
-        return
+	return
 }
 
 func parseInput(input string) (ans Grid) {
 	for i, line := range strings.Split(input, "\n") {
 		ans = append(ans, make([]*Position, 0, len(line)))
 		for _, c := range line {
-			pos := &Position{height: cast.ToInt(string(c))}
-			if pos.height == 9 {
-				pos.visited = make(map[Coordinate]struct{}, 0)
+			pos := &Position{height: IMPASSABLE}
+			if c != '.' {
+				pos.height = cast.ToInt(string(c))
 			}
 			ans[i] = append(ans[i], pos)
 		}
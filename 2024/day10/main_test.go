@@ -2,7 +2,10 @@ package main
 
 import (
 	"log/slog"
+	"reflect"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `89010123
@@ -34,6 +37,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -61,9 +67,192 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		scores  []int // Of each trailhead, in reading order
+		ratings []int
+	}{
+		{
+			name:    "example",
+			input:   example,
+			scores:  []int{5, 6, 5, 3, 1, 3, 5, 3, 5},
+			ratings: []int{20, 24, 10, 4, 1, 4, 5, 8, 5},
+		},
+		{
+			name: "forking",
+			input: `...0...
+...1...
+...2...
+6543456
+7.....7
+8.....8
+9.....9`,
+			scores:  []int{2},
+			ratings: []int{2},
+		},
+		{
+			name: "merging",
+			input: `.....0.
+..4321.
+..5..2.
+..6543.
+..7..4.
+..8765.
+..9....`,
+			scores:  []int{1},
+			ratings: []int{3},
+		},
+		{
+			name: "two trailheads",
+			input: `10..9..
+2...8..
+3...7..
+4567654
+...8..3
+...9..2
+.....01`,
+			scores:  []int{1, 2},
+			ratings: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := parseInput(tt.input)
+			trails := Evaluate(grid)
+			var scores, ratings []int
+			for _, head := range grid.Trailheads() {
+				scores = append(scores, trails.Score[head.i][head.j])
+				ratings = append(ratings, trails.Rating[head.i][head.j])
+			}
+			if !reflect.DeepEqual(scores, tt.scores) {
+				t.Errorf("Evaluate() scores = %v, want %v", scores, tt.scores)
+			}
+			if !reflect.DeepEqual(ratings, tt.ratings) {
+				t.Errorf("Evaluate() ratings = %v, want %v", ratings, tt.ratings)
+			}
+		})
+	}
+}
+
+func TestGrid_Trails(t *testing.T) {
+	grid := parseInput(`.....0.
+..4321.
+..5..2.
+..6543.
+..7..4.
+..8765.
+..9....`)
+	want := []string{
+		"(0,5) -> (1,5) -> (2,5) -> (3,5) -> (4,5) -> (5,5) -> (5,4) -> (5,3) -> (5,2) -> (6,2)",
+		"(0,5) -> (1,5) -> (2,5) -> (3,5) -> (3,4) -> (3,3) -> (3,2) -> (4,2) -> (5,2) -> (6,2)",
+		"(0,5) -> (1,5) -> (1,4) -> (1,3) -> (1,2) -> (2,2) -> (3,2) -> (4,2) -> (5,2) -> (6,2)",
+	}
+	var got []string
+	for _, trail := range grid.Trails(Coordinate{0, 5}) {
+		got = append(got, trail.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Trails() = %v, want %v", got, want)
+	}
+	rendered := `.....0.
+..4321.
+..5....
+..6....
+..7....
+..8....
+..9....
+`
+	if got := grid.Render(grid.Trails(Coordinate{0, 5})[2]); got != rendered {
+		t.Errorf("Render() = \n%v, want \n%v", got, rendered)
+	}
+	if got := grid.Trails(Coordinate{0, 0}); got != nil {
+		t.Errorf("Trails() from an impassable cell = %v, want none", got)
+	}
+}
+
+func TestGrid_Trails_rating(t *testing.T) {
+	if input == "" {
+		t.Skip("no input available, fetch it with `make input`")
+	}
+	grid := parseInput(input)
+	trails := Evaluate(grid)
+	for _, head := range grid.Trailheads() {
+		if got, want := len(grid.Trails(head)), trails.Rating[head.i][head.j]; got != want {
+			t.Errorf("Trails(%v) = %v trails, want %v", head, got, want)
+		}
+	}
+}
+
+// trailheadImpl is the recursive search from every trailhead which Evaluate replaced, keeping the
+// 9s reached from each trailhead in visited
+func trailheadImpl(from, start Coordinate, grid Grid, visited map[[2]Coordinate]bool, countTotal bool) int {
+	if grid[start.i][start.j].height == 9 {
+		if countTotal {
+			return 1
+		}
+		if !visited[[2]Coordinate{from, start}] {
+			visited[[2]Coordinate{from, start}] = true
+			return 1
+		}
+		return 0
+	}
+	ans := 0
+	for _, next := range grid.uphill(start) {
+		ans += trailheadImpl(from, next, grid, visited, countTotal)
+	}
+	return ans
+}
+
+func Test_trailheadImpl(t *testing.T) {
+	for _, countTotal := range []bool{false, true} {
+		grid := parseInput(example)
+		trails := Evaluate(grid)
+		visited := make(map[[2]Coordinate]bool)
+		for _, head := range grid.Trailheads() {
+			want := trails.Score[head.i][head.j]
+			if countTotal {
+				want = trails.Rating[head.i][head.j]
+			}
+			if got := trailheadImpl(head, head, grid, visited, countTotal); got != want {
+				t.Errorf("trailheadImpl(%v, %v) = %v, want %v", head, countTotal, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkTrails(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
+	grid := parseInput(input)
+	b.Run("recursive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			visited := make(map[[2]Coordinate]bool)
+			for _, head := range grid.Trailheads() {
+				trailheadImpl(head, head, grid, visited, false)
+				trailheadImpl(head, head, grid, visited, true)
+			}
+		}
+	})
+	b.Run("evaluate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Evaluate(grid)
+		}
+	})
+}
+
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
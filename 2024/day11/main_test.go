@@ -3,6 +3,8 @@ package main
 import (
 	"log/slog"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `125 17`
@@ -27,6 +29,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -49,6 +54,9 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
@@ -56,6 +64,10 @@ func Test_part2(t *testing.T) {
 	}
 }
 
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
+
 func recursiveImpl(stones *Stone, n int) int {
 	return blink(n, stones)
 }
@@ -68,6 +80,9 @@ func cachedImpl(stones *Stone, n int) (count int) {
 }
 
 func BenchmarkRecursive(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
 	for i := 0; i < b.N; i++ {
 		stones := parseInput(input)
 		recursiveImpl(stones, 25)
@@ -75,6 +90,9 @@ func BenchmarkRecursive(b *testing.B) {
 }
 
 func BenchmarkCached(b *testing.B) {
+	if input == "" {
+		b.Skip("no input available, fetch it with `make input`")
+	}
 	for i := 0; i < b.N; i++ {
 		stones := parseInput(input)
 		cachedImpl(stones, 25)
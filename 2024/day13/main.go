@@ -1,11 +1,12 @@
 package main
 
 import (
-	_ "embed"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"math"
+	"math/big"
 	"regexp"
 	"strings"
 
@@ -21,15 +22,15 @@ var prizeRe = regexp.MustCompile(`Prize: X=(\d+), Y=(\d+)`)
 const MAX_PRESSES = 100
 const INF = math.MaxInt
 
-//go:embed input.txt
+// PRIZE_OFFSET is how far the prizes really are in part 2
+const PRIZE_OFFSET = 10_000_000_000_000
+
 var input string
+var useBig bool
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = util.Input()
 }
 
 func main() {
@@ -37,12 +38,26 @@ func main() {
 	var debug bool
 	flag.IntVar(&part, "part", 1, "part 1 or 2")
 	flag.BoolVar(&debug, "debug", false, "debug mode")
+	readInput := util.InputFlags(flag.CommandLine)
+	flag.BoolVar(&useBig, "big", false, "solve with arbitrary precision integers (math/big)")
 	flag.Parse()
+	input = readInput()
 	fmt.Println("Running part", part)
 	if debug {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
 	}
 
+	if useBig {
+		// The answer may not fit in an int either
+		offset, maxPresses := 0, MAX_PRESSES
+		if part == 2 {
+			offset, maxPresses = PRIZE_OFFSET, INF
+		}
+		ans := play(input, offset, maxPresses)
+		util.CopyToClipboard(ans.String())
+		fmt.Println("Output:", ans)
+		return
+	}
 	if part == 1 {
 		ans := part1(input)
 		util.CopyToClipboard(fmt.Sprintf("%v", ans))
@@ -56,7 +71,13 @@ func main() {
 
 var ORIGIN = astar.Location{X: 0, Y: 0}
 
-func Cramer(a *astar.Arcade) (int, int, error) {
+var ErrOverflow = util.ErrOverflow
+var ErrNoSolution = errors.New("no whole number of presses")
+
+// Cramer finds how many times each button is pressed to get the prize, moved by offset in both
+// axes. The system is solved exactly with ints, returning ErrOverflow if any value doesn't fit
+// (see CramerBig).
+func Cramer(a *astar.Arcade, offset int) (int, int, error) {
 	// If we think of the game as a linalg problem to solve, Cramer rule is the way to go. Consider
 	// the following system of equations:
 	// 		A1 * x + A2 * y = Prize1
@@ -68,76 +89,137 @@ func Cramer(a *astar.Arcade) (int, int, error) {
 	// And the solution is:
 	// 		x = Dx / D
 	// 		y = Dy / D
-	// Where Dx and Dy are the determinants of the system with the Prize values
-	a1 := float64(a.Buttons["A"].Increment.X)
-	a2 := float64(a.Buttons["A"].Increment.Y)
-	b1 := float64(a.Buttons["B"].Increment.X)
-	b2 := float64(a.Buttons["B"].Increment.Y)
-	px := float64(a.Prize.X)
-	py := float64(a.Prize.Y)
-	det := a1*b2 - a2*b1
+	// Where Dx and Dy are the determinants of the system with the Prize values. There must be
+	// a whole number of presses, so D must divide both.
+	a1 := a.Buttons["A"].Increment.X
+	a2 := a.Buttons["A"].Increment.Y
+	b1 := a.Buttons["B"].Increment.X
+	b2 := a.Buttons["B"].Increment.Y
+	px, err1 := util.CheckedAdd(a.Prize.X, offset)
+	py, err2 := util.CheckedAdd(a.Prize.Y, offset)
+	if err := errors.Join(err1, err2); err != nil {
+		return 0, 0, err
+	}
+	det, err := determinant(a1, b1, a2, b2)
+	if err != nil {
+		return 0, 0, err
+	}
 	if det == 0 {
 		// We cannot solve the system. Use the A* algorithm to find the solution
-		path := a.AStar()
+		moved := *a
+		moved.Prize = astar.Location{X: px, Y: py}
+		path := moved.AStar()
 		if path == nil {
 			return 0, 0, fmt.Errorf("no path found")
 		}
 		buttonPresses := path.Count()
 		return buttonPresses["A"], buttonPresses["B"], nil
 	}
-	x := (px*b2 - py*b1) / det
-	y := (a1*py - a2*px) / det
-	return int(math.Trunc(x)), int(math.Trunc(y)), nil
+	dx, err1 := determinant(px, b1, py, b2)
+	dy, err2 := determinant(a1, px, a2, py)
+	if err := errors.Join(err1, err2); err != nil {
+		return 0, 0, err
+	}
+	if dx%det != 0 || dy%det != 0 {
+		return 0, 0, ErrNoSolution
+	}
+	return dx / det, dy / det, nil
 }
 
-func verify(a *astar.Arcade, x, y, max int) bool {
-	if x < 0 || y < 0 || x > max || y > max {
-		return false
+// CramerBig is the same as Cramer, with arbitrary precision so nothing overflows
+func CramerBig(a *astar.Arcade, offset int) (*big.Int, *big.Int, error) {
+	a1 := big.NewInt(int64(a.Buttons["A"].Increment.X))
+	a2 := big.NewInt(int64(a.Buttons["A"].Increment.Y))
+	b1 := big.NewInt(int64(a.Buttons["B"].Increment.X))
+	b2 := big.NewInt(int64(a.Buttons["B"].Increment.Y))
+	px := new(big.Int).Add(big.NewInt(int64(a.Prize.X)), big.NewInt(int64(offset)))
+	py := new(big.Int).Add(big.NewInt(int64(a.Prize.Y)), big.NewInt(int64(offset)))
+	det := determinantBig(a1, b1, a2, b2)
+	if det.Sign() == 0 {
+		// Only the A* algorithm can solve it, which doesn't overflow for the prizes it can reach
+		x, y, err := Cramer(a, offset)
+		return big.NewInt(int64(x)), big.NewInt(int64(y)), err
+	}
+	x, remX := new(big.Int).QuoRem(determinantBig(px, b1, py, b2), det, new(big.Int))
+	y, remY := new(big.Int).QuoRem(determinantBig(a1, px, a2, py), det, new(big.Int))
+	if remX.Sign() != 0 || remY.Sign() != 0 {
+		return nil, nil, ErrNoSolution
 	}
-	return a.Buttons["A"].Increment.X*x+a.Buttons["B"].Increment.X*y == a.Prize.X &&
-		a.Buttons["A"].Increment.Y*x+a.Buttons["B"].Increment.Y*y == a.Prize.Y
+	return x, y, nil
 }
 
-func part1(input string) (cost int) {
-	arcades := parseInput(input)
-	for _, arcade := range arcades {
-		arcade.MaxPresses = MAX_PRESSES
-		a, b, err := Cramer(arcade)
-		if err != nil {
-			slog.Warn("error solving system", "arcade", arcade, "error", err)
-			continue
-		}
-		if !verify(arcade, a, b, MAX_PRESSES) {
-			slog.Warn("invalid solution", "arcade", arcade, "a", a, "b", b)
-			continue
+// determinant returns the determinant of the matrix with columns (a, c) and (b, d)
+func determinant(a, b, c, d int) (int, error) {
+	ad, err1 := util.CheckedMul(a, d)
+	bc, err2 := util.CheckedMul(b, c)
+	if err := errors.Join(err1, err2); err != nil {
+		return 0, err
+	}
+	return util.CheckedSub(ad, bc)
+}
+
+func determinantBig(a, b, c, d *big.Int) *big.Int {
+	ad := new(big.Int).Mul(a, d)
+	return ad.Sub(ad, new(big.Int).Mul(b, c))
+}
+
+// solve solves the arcade with ints, unless -big is set. If any value overflows, it's solved
+// again with big integers.
+func solve(a *astar.Arcade, offset int) (*big.Int, *big.Int, error) {
+	if !useBig {
+		x, y, err := Cramer(a, offset)
+		if !errors.Is(err, ErrOverflow) {
+			return big.NewInt(int64(x)), big.NewInt(int64(y)), err
 		}
-		slog.Debug("solution", "arcade", arcade, "a", a, "b", b)
-		cost += a*arcade.Buttons["A"].Tokens + b*arcade.Buttons["B"].Tokens
+		slog.Debug("Overflow, solving with big integers", "arcade", a)
 	}
+	return CramerBig(a, offset)
+}
 
-	return
+// verify checks the presses are not negative, nor above max unless it's INF: big solutions may
+// take more presses than fit in an int
+func verify(x, y *big.Int, max int) bool {
+	if x.Sign() < 0 || y.Sign() < 0 {
+		return false
+	}
+	return max == INF || (x.Cmp(big.NewInt(int64(max))) <= 0 && y.Cmp(big.NewInt(int64(max))) <= 0)
 }
 
-func part2(input string) (cost int) {
-	arcades := parseInput(input)
-	for _, arcade := range arcades {
-		arcade.MaxPresses = INF
-		arcade.Prize.X += 10_000_000_000_000
-		arcade.Prize.Y += 10_000_000_000_000
-		a, b, err := Cramer(arcade)
+// play returns the tokens needed to win every prize that can be won, moving the prizes by offset
+func play(input string, offset, maxPresses int) *big.Int {
+	cost := new(big.Int)
+	for _, arcade := range parseInput(input) {
+		arcade.MaxPresses = maxPresses
+		a, b, err := solve(arcade, offset)
 		if err != nil {
 			slog.Warn("error solving system", "arcade", arcade, "error", err)
 			continue
 		}
-		if !verify(arcade, a, b, INF) {
+		if !verify(a, b, maxPresses) {
 			slog.Warn("invalid solution", "arcade", arcade, "a", a, "b", b)
 			continue
 		}
 		slog.Debug("solution", "arcade", arcade, "a", a, "b", b)
-		cost += a*arcade.Buttons["A"].Tokens + b*arcade.Buttons["B"].Tokens
+		tokensA := new(big.Int).Mul(a, big.NewInt(int64(arcade.Buttons["A"].Tokens)))
+		tokensB := new(big.Int).Mul(b, big.NewInt(int64(arcade.Buttons["B"].Tokens)))
+		cost.Add(cost, tokensA.Add(tokensA, tokensB))
 	}
+	return cost
+}
 
-	return
+func part1(input string) int {
+	return toInt(play(input, 0, MAX_PRESSES))
+}
+
+func part2(input string) int {
+	return toInt(play(input, PRIZE_OFFSET, INF))
+}
+
+func toInt(n *big.Int) int {
+	if !n.IsInt64() {
+		panic(fmt.Sprintf("%s doesn't fit in an int, run with -big", n))
+	}
+	return int(n.Int64())
 }
 
 func parseInput(input string) (arcades []*astar.Arcade) {
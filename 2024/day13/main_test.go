@@ -1,8 +1,12 @@
 package main
 
 import (
+	"errors"
 	"log/slog"
+	"math"
 	"testing"
+
+	"github.com/Javinator9889/aoc-2024/harness"
 )
 
 var example = `Button A: X+94, Y+34
@@ -50,6 +54,9 @@ func Test_part1(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part1(tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
@@ -77,9 +84,79 @@ func Test_part2(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.input == "" {
+				t.Skip("no input available, fetch it with `make input`")
+			}
 			if got := part2(tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+// Solved with 8e16 and 4e16 presses, but the determinants don't fit in an int
+var overflowing = `Button A: X+94, Y+34
+Button B: X+22, Y+67
+Prize: X=8400000000000000000, Y=5400000000000000000`
+
+// Solved with 9e18 presses each, but the tokens don't fit in an int
+var expensive = `Button A: X+1, Y+0
+Button B: X+0, Y+1
+Prize: X=9000000000000000000, Y=9000000000000000000`
+
+func TestCramer(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		offset  int
+		want    [2]int
+		wantErr error
+	}{
+		{"example", example, 0, [2]int{80, 40}, nil},
+		{"moved", example, PRIZE_OFFSET, [2]int{}, ErrNoSolution},
+		{"overflowing", overflowing, 0, [2]int{}, ErrOverflow},
+		{"moved overflowing", expensive, math.MaxInt, [2]int{}, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arcade := parseInput(tt.input)[0]
+			x, y, err := Cramer(arcade, tt.offset)
+			if !errors.Is(err, tt.wantErr) || [2]int{x, y} != tt.want {
+				t.Errorf("Cramer() = %v, %v, %v, want %v, %v", x, y, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_play(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		offset     int
+		maxPresses int
+		useBig     bool
+		want       string
+	}{
+		{"example", example, 0, MAX_PRESSES, true, "480"},
+		{"example moved", example, PRIZE_OFFSET, INF, true, "875318608908"},
+		{"overflowing", overflowing, 0, INF, false, "280000000000000000"},
+		{"overflowing big", overflowing, 0, INF, true, "280000000000000000"},
+		{"expensive", expensive, 0, INF, false, "36000000000000000000"},
+		// 1.8e19 presses each, more than fit in an int
+		{"beyond max int presses", expensive, 9000000000000000000, INF, false, "72000000000000000000"},
+		{"beyond max int presses big", expensive, 9000000000000000000, INF, true, "72000000000000000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(old bool) { useBig = old }(useBig)
+			useBig = tt.useBig
+			if got := play(tt.input, tt.offset, tt.maxPresses); got.String() != tt.want {
+				t.Errorf("play() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
@@ -0,0 +1,56 @@
+// Package harness runs the solutions of a day against the inputs of every team profile,
+// checking the results against the answers recorded for each of them.
+package harness
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Javinator9889/aoc-2024/util"
+)
+
+// Part is the solution to one of the parts of a day
+type Part func(input string) int
+
+// Profiles runs the given parts (in order: part 1, part 2...) against the input of every profile
+// found for the calling day, comparing with the answers in `answers.<profile>.txt`. Parts
+// without a recorded answer are skipped, as there's nothing to compare against.
+func Profiles(t *testing.T, parts ...Part) {
+	t.Helper()
+	_, filename, _, ok := runtime.Caller(1)
+	if !ok {
+		t.Fatal("Could not find Caller of harness.Profiles")
+	}
+	dayDir := filepath.Dir(filename)
+	inputsDir := os.Getenv(util.InputsDirEnv)
+
+	profiles := util.Profiles(dayDir, inputsDir)
+	if len(profiles) == 0 {
+		t.Skip("no profile inputs available, fetch them with `make input PROFILE=<name>`")
+	}
+	for _, profile := range profiles {
+		t.Run(profile, func(t *testing.T) {
+			input := util.ProfileInput(dayDir, inputsDir, profile)
+			if input == "" {
+				t.Skipf("empty input for profile %s", profile)
+			}
+			answers, err := util.Answers(dayDir, inputsDir, profile)
+			if err != nil {
+				t.Fatalf("reading answers of profile %s: %v", profile, err)
+			}
+			for i, part := range parts {
+				t.Run(fmt.Sprintf("part%d", i+1), func(t *testing.T) {
+					if i >= len(answers) || answers[i] == "" {
+						t.Skipf("no answer recorded in %s", util.AnswersName(profile))
+					}
+					if got := fmt.Sprint(part(input)); got != answers[i] {
+						t.Errorf("part%d() = %v, want %v", i+1, got, answers[i])
+					}
+				})
+			}
+		})
+	}
+}
@@ -3,48 +3,76 @@ package aoc
 
 import (
 	"context"
-	"flag"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/Javinator9889/aoc-2024/store"
 )
 
-func ParseFlags() (day, year int, cookie string) {
-	today := time.Now()
-	flag.IntVar(&day, "day", today.Day(), "day number to fetch, 1-25")
-	flag.IntVar(&year, "year", today.Year(), "AOC year")
-	// defaults to env variable
-	flag.StringVar(&cookie, "cookie", os.Getenv("AOC_SESSION_COOKIE"), "AOC session cookie")
-	flag.Parse()
+// BaseURLEnv is the env var overriding the address of adventofcode.com, e.g. to use the fake
+// server of the aoctest package
+const BaseURLEnv = "AOC_BASE_URL"
 
-	if day > 25 || day < 1 {
-		log.Fatalf("day out of range: %d", day)
-	}
+// DefaultBaseURL is the address of adventofcode.com
+const DefaultBaseURL = "https://adventofcode.com"
 
-	if year < 2015 {
-		log.Fatalf("year is before 2015: %d", year)
+// BaseURL returns the address requests are sent to, without a trailing slash
+func BaseURL() string {
+	if url := os.Getenv(BaseURLEnv); url != "" {
+		return strings.TrimSuffix(url, "/")
 	}
+	return DefaultBaseURL
+}
 
-	if cookie == "" {
-		log.Fatalf("no session cookie set on flag or env var (AOC_SESSION_COOKIE)")
-	}
+// ErrAuth is returned when a response looks like the session cookie is missing, invalid or
+// expired, see CheckAuth
+var ErrAuth = errors.New("not logged in to adventofcode.com")
+
+// ErrThrottled is returned when the site asks to stop repeating a request
+var ErrThrottled = errors.New("repeated request, please slow down")
 
-	return day, year, cookie
+// StatusError is returned on unexpected HTTP responses
+type StatusError struct {
+	Code int
+	Body string // Start of the response body
 }
 
-func GetWithAOCCookie(url string, cookie string) []byte {
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected response %d %s: %s", e.Code, http.StatusText(e.Code), e.Body)
+}
+
+func GetWithAOCCookie(url string, cookie string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		log.Fatalf("making request: %s", err)
+		return nil, fmt.Errorf("making request: %w", err)
 	}
+	return doWithAOCCookie(req, cookie)
+}
 
+// PostWithAOCCookie posts the form to the url, e.g. to submit an answer
+func PostWithAOCCookie(url string, form url.Values, cookie string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("making request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doWithAOCCookie(req, cookie)
+}
+
+func doWithAOCCookie(req *http.Request, cookie string) ([]byte, error) {
 	sessionCookie := http.Cookie{
 		Name:  "session",
 		Value: cookie,
@@ -53,21 +81,28 @@ func GetWithAOCCookie(url string, cookie string) []byte {
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
-		log.Fatalf("making request: %s", err)
+		return nil, fmt.Errorf("making request: %w", err)
 	}
+	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		log.Fatalf("reading response body: %s", err)
+		return nil, fmt.Errorf("reading response body: %w", err)
 	}
-	fmt.Println("response length is", len(body))
+	slog.Debug("response", "url", req.URL.String(), "length", len(body))
 
 	// specific error message from AOC site
 	if strings.HasPrefix(string(body), "Please don't repeatedly") {
-		log.Fatalf("Repeated request github.com/Javinator9889/aoc-2024 error")
+		return nil, ErrThrottled
+	}
+	if looksUnauthenticated(body) {
+		return nil, ErrAuth
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, &StatusError{res.StatusCode, truncate(strings.TrimSpace(string(body)), 80)}
 	}
 
-	return body
+	return body, nil
 }
 
 func WriteToFile(filename string, contents []byte) {
@@ -80,3 +115,13 @@ func WriteToFile(filename string, contents []byte) {
 		log.Fatalf("writing file: %s", err)
 	}
 }
+
+// WriteToStore is like WriteToFile but encrypts the contents if $AOC_INPUTS_KEY is set, see the
+// store package. Returns the path actually written.
+func WriteToStore(filename string, contents []byte) string {
+	written, err := store.WriteFile(filename, contents, os.FileMode(0644))
+	if err != nil {
+		log.Fatalf("writing file: %s", err)
+	}
+	return written
+}
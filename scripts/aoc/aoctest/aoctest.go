@@ -0,0 +1,323 @@
+// Package aoctest provides a fake adventofcode.com serving puzzles, inputs, answer verdicts and
+// leaderboards from fixtures, so the aoc package can be tested offline. Point the aoc package to
+// it by setting $AOC_BASE_URL to Server.URL.
+package aoctest
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Fixtures are the bundled fixtures, laid out as:
+//
+//	YYYY/dayNN/part1.html   the <article> of the first part of the puzzle
+//	YYYY/dayNN/part2.html   the <article> of the second part, shown once the first is solved
+//	YYYY/dayNN/input.txt    the input
+//	YYYY/dayNN/answers.txt  the answer of each part, one per line
+//	YYYY/self.html          the personal times, shown inside a <pre>
+//	YYYY/leaderboard/ID.json
+var Fixtures fs.FS
+
+func init() {
+	var err error
+	if Fixtures, err = fs.Sub(fixtures, "fixtures"); err != nil {
+		panic(err)
+	}
+}
+
+const (
+	Session = "53616c7465645f5f"   // The session cookie accepted by default
+	User    = "alice"              // The user logged in with Session
+	Year    = 2024                 // The year of the bundled fixtures
+	Day     = 1                    // The day of the bundled puzzle
+	Board   = "123456"             // The ID of the bundled private leaderboard
+	Answer1 = "6"                  // The answer to the first part of the bundled puzzle
+	Answer2 = "14"                 // The answer to the second part of the bundled puzzle
+	Input   = "1\n2\n3\n"          // The input of the bundled puzzle
+	Title   = "Day 1: Fake Puzzle" // The title of the bundled puzzle
+)
+
+// Throttle is the time to wait after a wrong answer, as adventofcode.com does
+const Throttle = time.Minute
+
+// A puzzle is a day of a year
+type puzzle struct{ year, day int }
+
+// Server is a fake adventofcode.com. Only the Session is logged in, as User.
+type Server struct {
+	*httptest.Server
+	Session string
+	User    string
+
+	fixtures fs.FS
+	mu       sync.Mutex
+	solved   map[puzzle]int // Number of parts solved
+	wrongAt  time.Time      // Time of the last wrong answer
+	requests map[string]int // Number of requests by path
+}
+
+// NewServer starts a server with the given fixtures, see Fixtures. The caller should call Close
+// when finished, to shut it down.
+func NewServer(fixtures fs.FS) *Server {
+	s := &Server{
+		Session:  Session,
+		User:     User,
+		fixtures: fixtures,
+		solved:   map[puzzle]int{},
+		requests: map[string]int{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /settings", s.settings)
+	mux.HandleFunc("GET /{year}", s.calendar)
+	mux.HandleFunc("GET /{year}/day/{day}", s.puzzle)
+	mux.HandleFunc("GET /{year}/day/{day}/input", s.input)
+	mux.HandleFunc("POST /{year}/day/{day}/answer", s.answer)
+	mux.HandleFunc("GET /{year}/leaderboard/self", s.self)
+	mux.HandleFunc("GET /{year}/leaderboard/private/view/{file}", s.leaderboard)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	return s
+}
+
+// Requests returns the number of requests received for the given path
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+// Solve marks the given number of parts of a puzzle as solved
+func (s *Server) Solve(year, day, parts int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.solved[puzzle{year, day}] = parts
+}
+
+func (s *Server) loggedIn(r *http.Request) bool {
+	c, err := r.Cookie("session")
+	return err == nil && c.Value == s.Session
+}
+
+// page writes an HTML page whose header shows the logged-in user, or a link to log in
+func (s *Server) page(w http.ResponseWriter, r *http.Request, main string) {
+	header := `<nav><ul><li><a href="/auth/login">[Log In]</a></li></ul></nav>`
+	if s.loggedIn(r) {
+		s.mu.Lock()
+		stars := 0
+		for _, parts := range s.solved {
+			stars += parts
+		}
+		s.mu.Unlock()
+		header = fmt.Sprintf(`<div class="user">%s <span class="star-count">%d*</span></div>`, s.User, stars)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en-us\">\n<body>\n<header>%s</header>\n<main>\n%s\n</main>\n</body>\n</html>\n", header, main)
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	s.page(w, r, `<article><p>To play, please identify yourself via one of these services:</p></article>`)
+}
+
+// notUnlocked is the response to days without fixtures
+func notUnlocked(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprintln(w, "Please don't repeatedly request this endpoint before it unlocks! The calendar countdown is synchronized with the server time; the link will be enabled on the calendar the instant this puzzle becomes available.")
+}
+
+// puzzleOf reads the year and day of the request, and whether there are fixtures for them
+func (s *Server) puzzleOf(r *http.Request) (puzzle, string, bool) {
+	year, err1 := strconv.Atoi(r.PathValue("year"))
+	day, err2 := strconv.Atoi(r.PathValue("day"))
+	dir := fmt.Sprintf("%d/day%02d", year, day)
+	if err1 != nil || err2 != nil {
+		return puzzle{}, dir, false
+	}
+	_, err := fs.Stat(s.fixtures, path.Join(dir, "part1.html"))
+	return puzzle{year, day}, dir, err == nil
+}
+
+func (s *Server) settings(w http.ResponseWriter, r *http.Request) {
+	if !s.loggedIn(r) {
+		s.login(w, r)
+		return
+	}
+	s.page(w, r, `<article><p>Settings</p></article>`)
+}
+
+func (s *Server) calendar(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var calendar strings.Builder
+	calendar.WriteString(`<pre class="calendar">` + "\n")
+	for day := 25; day >= 1; day-- {
+		if _, err := fs.Stat(s.fixtures, fmt.Sprintf("%d/day%02d/part1.html", year, day)); err != nil {
+			continue
+		}
+		s.mu.Lock()
+		solved := s.solved[puzzle{year, day}]
+		s.mu.Unlock()
+		class, label := fmt.Sprintf("calendar-day%d", day), fmt.Sprintf("Day %d", day)
+		switch {
+		case solved == 1 && s.loggedIn(r):
+			class, label = class+" calendar-complete", label+", one star"
+		case solved == 2 && s.loggedIn(r):
+			class, label = class+" calendar-verycomplete", label+", two stars"
+		}
+		fmt.Fprintf(&calendar, "<a aria-label=%q href=\"/%d/day/%d\" class=%q><span class=\"calendar-day\">%2d</span></a>\n", label, year, day, class, day)
+	}
+	calendar.WriteString("</pre>")
+	s.page(w, r, calendar.String())
+}
+
+func (s *Server) puzzle(w http.ResponseWriter, r *http.Request) {
+	p, dir, ok := s.puzzleOf(r)
+	if !ok {
+		notUnlocked(w)
+		return
+	}
+	parts := []string{"part1.html"}
+	s.mu.Lock()
+	if s.solved[p] >= 1 && s.loggedIn(r) {
+		parts = append(parts, "part2.html")
+	}
+	s.mu.Unlock()
+	var main strings.Builder
+	for _, part := range parts {
+		article, err := fs.ReadFile(s.fixtures, path.Join(dir, part))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		main.Write(article)
+	}
+	s.page(w, r, main.String())
+}
+
+func (s *Server) input(w http.ResponseWriter, r *http.Request) {
+	if !s.loggedIn(r) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.")
+		return
+	}
+	_, dir, ok := s.puzzleOf(r)
+	if !ok {
+		notUnlocked(w)
+		return
+	}
+	input, err := fs.ReadFile(s.fixtures, path.Join(dir, "input.txt"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(input)
+}
+
+func (s *Server) answer(w http.ResponseWriter, r *http.Request) {
+	if !s.loggedIn(r) {
+		s.login(w, r)
+		return
+	}
+	p, dir, ok := s.puzzleOf(r)
+	if !ok {
+		notUnlocked(w)
+		return
+	}
+	answers, err := fs.ReadFile(s.fixtures, path.Join(dir, "answers.txt"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	level, _ := strconv.Atoi(r.FormValue("level"))
+	given := strings.TrimSpace(r.FormValue("answer"))
+
+	s.page(w, r, fmt.Sprintf("<article><p>%s</p></article>", s.check(p, level, given, string(answers))))
+}
+
+// check returns the response to the answer given to a level of the puzzle
+func (s *Server) check(p puzzle, level int, given, answers string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch wait := Throttle - time.Since(s.wrongAt); {
+	case level != s.solved[p]+1:
+		return "You don't seem to be solving the right level.  Did you already complete it?"
+	case wait > 0:
+		left := fmt.Sprintf("%ds", int(wait.Seconds())%60)
+		if minutes := int(wait.Minutes()); minutes > 0 {
+			left = fmt.Sprintf("%dm %s", minutes, left)
+		}
+		return fmt.Sprintf("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %s left to wait.", left)
+	}
+	lines := strings.Split(strings.TrimSpace(answers), "\n")
+	want := ""
+	if level <= len(lines) {
+		want = strings.TrimSpace(lines[level-1])
+	}
+	if given == want {
+		s.solved[p] = level
+	} else {
+		s.wrongAt = time.Now()
+	}
+	return verdict(given, want)
+}
+
+func verdict(given, want string) string {
+	if given == want {
+		return "That's the right answer!  You are one gold star closer to finding the Chief Historian."
+	}
+	hint := ""
+	g, err1 := strconv.Atoi(given)
+	w, err2 := strconv.Atoi(want)
+	switch {
+	case err1 == nil && err2 == nil && g > w:
+		hint = "; your answer is too high"
+	case err1 == nil && err2 == nil && g < w:
+		hint = "; your answer is too low"
+	}
+	return fmt.Sprintf("That's not the right answer%s.  Please wait one minute before trying again.", hint)
+}
+
+func (s *Server) self(w http.ResponseWriter, r *http.Request) {
+	if !s.loggedIn(r) {
+		s.login(w, r)
+		return
+	}
+	times, err := fs.ReadFile(s.fixtures, path.Join(r.PathValue("year"), "self.html"))
+	if err != nil {
+		times = []byte("You haven't collected any stars... yet.")
+	}
+	s.page(w, r, fmt.Sprintf("<article><pre>%s</pre></article>", times))
+}
+
+func (s *Server) leaderboard(w http.ResponseWriter, r *http.Request) {
+	if !s.loggedIn(r) {
+		s.login(w, r)
+		return
+	}
+	id, ok := strings.CutSuffix(r.PathValue("file"), ".json")
+	data, err := fs.ReadFile(s.fixtures, path.Join(r.PathValue("year"), "leaderboard", id+".json"))
+	if !ok || err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
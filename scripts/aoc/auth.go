@@ -0,0 +1,109 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Texts found in the responses of adventofcode.com when the session is not logged in
+var loggedOutMarkers = []string{
+	"Puzzle inputs differ by user",      // The input of a day
+	"To play, please identify yourself", // The login page
+	`href="/auth/login"`,                // The [Log In] link in the header of every page
+}
+
+func looksUnauthenticated(body []byte) bool {
+	for _, marker := range loggedOutMarkers {
+		if bytes.Contains(body, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAuth fetches a cheap authenticated page and returns the name of the logged-in user. If
+// the session cookie is invalid or expired, ErrAuth is returned.
+func CheckAuth(cookie string) (string, error) {
+	if cookie == "" {
+		return "", fmt.Errorf("%w: no session cookie set", ErrAuth)
+	}
+	body, err := GetWithAOCCookie(BaseURL()+"/settings", cookie)
+	if err != nil {
+		return "", err
+	}
+	user := parseUser(body)
+	if user == "" {
+		return "", ErrAuth
+	}
+	return user, nil
+}
+
+// parseUser returns the user name shown in the header of the page, e.g. `<div class="user">alice
+// <span class="star-count">42*</span></div>`. Users without a public name are shown as
+// "(anonymous user #123456)".
+func parseUser(body []byte) string {
+	node, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	users := dfsHTML(node, func(n *html.Node) []interface{} {
+		if n.Type != html.ElementNode || n.Data != "div" {
+			return nil
+		}
+		for _, attr := range n.Attr {
+			if attr.Key == "class" && attr.Val == "user" {
+				return []interface{}{n}
+			}
+		}
+		return nil
+	})
+	if len(users) == 0 {
+		return ""
+	}
+	// Only the direct text, the children hold the star count and supporter badges
+	var name strings.Builder
+	for child := users[0].(*html.Node).FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.TextNode {
+			name.WriteString(child.Data)
+		}
+	}
+	return strings.TrimSpace(name.String())
+}
+
+// AuthHint checks the session again (see CheckAuth) on errors which may be caused by it, such
+// as ErrAuth or a 4xx response, and explains how to fix it if the session is not valid
+func AuthHint(err error, cookie string) error {
+	var status *StatusError
+	if !errors.Is(err, ErrAuth) && (!errors.As(err, &status) || status.Code >= 500) {
+		return err
+	}
+	user, authErr := CheckAuth(cookie)
+	if authErr == nil {
+		return fmt.Errorf("%w (the session cookie is valid, logged in as %s)", err, user)
+	}
+	if !errors.Is(authErr, ErrAuth) {
+		return err
+	}
+	return ExplainAuth(err)
+}
+
+// ExplainAuth wraps an ErrAuth with the steps to get a new session cookie
+func ExplainAuth(err error) error {
+	return fmt.Errorf(
+		"%w: the session cookie is invalid or expired. Log in to %s, copy the "+
+			"value of its `session` cookie and set it as AOC_SESSION_COOKIE (env var or .env file) or "+
+			"as `session` in %s, then run `aoc auth check`",
+		err, BaseURL(), ConfigPath(),
+	)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,120 @@
+package aoc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_parseUser(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "logged in",
+			body: `<header><div><h1 class="title-global"><a href="/">Advent of Code</a></h1><div class="user">alice <span class="star-count">42*</span></div></div></header>`,
+			want: "alice",
+		},
+		{
+			name: "anonymous",
+			body: `<header><div class="user">(anonymous user #123456) <a href="/support" class="supporter-badge">AoC++</a> <span class="star-count">8*</span></div></header>`,
+			want: "(anonymous user #123456)",
+		},
+		{
+			name: "logged out",
+			body: `<header><nav><ul><li><a href="/auth/login">[Log In]</a></li></ul></nav></header>`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseUser([]byte(tt.body)); got != tt.want {
+				t.Errorf("parseUser() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWithAOCCookie(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			body:   "1 2 3\n",
+		},
+		{
+			name:    "expired input",
+			status:  http.StatusBadRequest,
+			body:    "Puzzle inputs differ by user.  Please log in to get your puzzle input.\n",
+			wantErr: ErrAuth,
+		},
+		{
+			name:    "logged out page",
+			status:  http.StatusOK,
+			body:    `<html><body><a href="/auth/login">[Log In]</a><main>...</main></body></html>`,
+			wantErr: ErrAuth,
+		},
+		{
+			name:    "throttled",
+			status:  http.StatusNotFound,
+			body:    "Please don't repeatedly request this endpoint before it unlocks! The calendar countdown is synchronized with the server time; the link will be enabled on the calendar the instant this puzzle becomes available.\n",
+			wantErr: ErrThrottled,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			body:    "oops",
+			wantErr: &StatusError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if c, err := r.Cookie("session"); err != nil || c.Value != "cookie" {
+					t.Errorf("session cookie = %v, %v", c, err)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, err := GetWithAOCCookie(server.URL, "cookie")
+			var status *StatusError
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("GetWithAOCCookie() error = %v", err)
+			case tt.wantErr == nil && string(got) != tt.body:
+				t.Errorf("GetWithAOCCookie() = %q, want %q", got, tt.body)
+			case errors.As(tt.wantErr, &status):
+				if !errors.As(err, &status) || status.Code != tt.status {
+					t.Errorf("GetWithAOCCookie() error = %v, want status %d", err, tt.status)
+				}
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Errorf("GetWithAOCCookie() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthHint(t *testing.T) {
+	other := errors.New("connection refused")
+	if got := AuthHint(other, "cookie"); got != other {
+		t.Errorf("AuthHint() = %v, want the error unchanged", got)
+	}
+	server := &StatusError{Code: http.StatusBadGateway}
+	if got := AuthHint(server, "cookie"); got != error(server) {
+		t.Errorf("AuthHint() = %v, want the error unchanged", got)
+	}
+	// Without a session there's nothing to check, so the error is explained right away
+	if got := AuthHint(ErrAuth, ""); !errors.Is(got, ErrAuth) || got.Error() == ErrAuth.Error() {
+		t.Errorf("AuthHint() = %v, want an explained ErrAuth", got)
+	}
+}
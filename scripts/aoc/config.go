@@ -0,0 +1,99 @@
+package aoc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Config holds the sections of a (minimal) TOML config file, such as:
+//
+//	[profiles.alice]
+//	session = "53616c746564..."
+//
+// Keys outside of any section belong to the "" section. Only string, integer and boolean
+// values are supported, which is all we need.
+type Config map[string]map[string]string
+
+// ConfigPath returns the default location of the config file, `~/.config/aoc/config.toml`
+// (honouring $XDG_CONFIG_HOME, see os.UserConfigDir)
+func ConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc", "config.toml")
+}
+
+// LoadConfig reads the config file at path. A missing file yields an empty config.
+func LoadConfig(path string) (Config, error) {
+	config := Config{}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) || path == "" {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return config, config.parse(f, path)
+}
+
+func (c Config) parse(f *os.File, path string) error {
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("%s:%d: invalid section %q", path, n, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%s:%d: expected key = value, got %q", path, n, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			quoted, err := strconv.QuotedPrefix(value)
+			if err != nil {
+				return fmt.Errorf("%s:%d: invalid string %s", path, n, value)
+			}
+			value, _ = strconv.Unquote(quoted)
+		} else if i := strings.Index(value, "#"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		c.Set(section, key, value)
+	}
+	return scanner.Err()
+}
+
+// Get returns the value of key in section, or an empty string
+func (c Config) Get(section, key string) string {
+	return c[section][key]
+}
+
+// Set sets the value of key in section
+func (c Config) Set(section, key, value string) {
+	if c[section] == nil {
+		c[section] = map[string]string{}
+	}
+	c[section][key] = value
+}
+
+// Session returns the session cookie of the given profile
+func (c Config) Session(profile string) (string, error) {
+	session := c.Get("profiles."+profile, "session")
+	if session == "" {
+		return "", fmt.Errorf("no session set for profile %q in %s", profile, ConfigPath())
+	}
+	return session, nil
+}
@@ -0,0 +1,48 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `# team accounts
+year = 2024
+
+[profiles.alice]
+session = "abc123" # personal account
+
+[profiles.bob]
+session = "def456"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	tests := []struct {
+		section, key string
+		want         string
+	}{
+		{"", "year", "2024"},
+		{"profiles.alice", "session", "abc123"},
+		{"profiles.bob", "session", "def456"},
+		{"profiles.carol", "session", ""},
+	}
+	for _, tt := range tests {
+		if got := config.Get(tt.section, tt.key); got != tt.want {
+			t.Errorf("Get(%q, %q) = %q, want %q", tt.section, tt.key, got, tt.want)
+		}
+	}
+	if _, err := config.Session("carol"); err == nil {
+		t.Errorf("Session() of an unknown profile should fail")
+	}
+
+	if config, err := LoadConfig(filepath.Join(t.TempDir(), "missing.toml")); err != nil || len(config) != 0 {
+		t.Errorf("LoadConfig() of a missing file = %v, %v, want empty config", config, err)
+	}
+}
@@ -0,0 +1,172 @@
+package aoc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Javinator9889/aoc-2024/scripts/skeleton"
+	"github.com/Javinator9889/aoc-2024/store"
+	"github.com/Javinator9889/aoc-2024/util"
+)
+
+// Key is a setting which can be set in the config file, the .env file or the environment
+type Key struct {
+	Name   string // Name in the config file, e.g. "session"
+	Env    string // Env var, e.g. "AOC_SESSION_COOKIE"
+	Secret bool   // Whether the value must be redacted when shown
+}
+
+// Keys are every known setting, in the order they are shown
+var Keys = []Key{
+	{Name: "day", Env: "AOC_DAY"},
+	{Name: "year", Env: "AOC_YEAR"},
+	{Name: "session", Env: "AOC_SESSION_COOKIE", Secret: true},
+	{Name: "profile", Env: "AOC_PROFILE"},
+	{Name: "inputs_dir", Env: util.InputsDirEnv},
+	{Name: "inputs_key", Env: store.KeyEnv, Secret: true},
+	{Name: "templates", Env: skeleton.TemplatesEnv},
+	{Name: "leaderboard", Env: "AOC_LEADERBOARD"}, // ID of the private leaderboard
+	{Name: "base_url", Env: BaseURLEnv},
+}
+
+// Source tells where the value of a setting comes from
+type Source string
+
+const (
+	DEFAULT Source = "default"
+	CONFIG  Source = "config file"
+	DOTENV  Source = ".env"
+	ENV     Source = "env"
+	FLAG    Source = "flag"
+)
+
+// Value is the value of a setting along with its source
+type Value struct {
+	Value  string
+	Source Source
+}
+
+// Values are the values of the settings by name, see Keys
+type Values map[string]Value
+
+// set overrides the value of a setting, unless it's empty
+func (v Values) set(name, value string, source Source) {
+	if value != "" {
+		v[name] = Value{value, source}
+	}
+}
+
+// DotEnvPath returns the location of the .env file, at the root of the repository
+func DotEnvPath() string {
+	return filepath.Join(util.Root(), ".env")
+}
+
+// dotEnvPath is swapped by the tests, so they don't pick up the repository's own .env
+var dotEnvPath = DotEnvPath
+
+// LoadDotEnv reads the `KEY=value` lines of a .env file. Comments, blank lines, `export`
+// prefixes and quoted values are supported. A missing file yields no variables.
+func LoadDotEnv(path string) (map[string]string, error) {
+	vars := map[string]string{}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return vars, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=value, got %q", path, n, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`):
+			quoted, err := strconv.QuotedPrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid string %s", path, n, value)
+			}
+			value, _ = strconv.Unquote(quoted)
+		case strings.HasPrefix(value, "'"):
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("%s:%d: invalid string %s", path, n, value)
+			}
+			value = value[1 : end+1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		vars[key] = value
+	}
+	return vars, scanner.Err()
+}
+
+// Lookup layers the settings by increasing priority from: the defaults, the config file, the
+// .env file and the env vars
+func Lookup() (Values, error) {
+	config, err := LoadConfig(ConfigPath())
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	dotenv, err := LoadDotEnv(dotEnvPath())
+	if err != nil {
+		return nil, fmt.Errorf("loading .env: %w", err)
+	}
+
+	now := time.Now()
+	values := Values{"year": {strconv.Itoa(defaultYear(now)), DEFAULT}}
+	if day := util.Today(now); day != 0 {
+		values["day"] = Value{strconv.Itoa(day), DEFAULT}
+	}
+	for _, key := range Keys {
+		values.set(key.Name, config.Get("", key.Name), CONFIG)
+		values.set(key.Name, dotenv[key.Env], DOTENV)
+		// An env var matching the value found so far was most likely exported by Values.Export,
+		// so it keeps its original source
+		if env := os.Getenv(key.Env); env != values[key.Name].Value {
+			values.set(key.Name, env, ENV)
+		}
+	}
+	return values, nil
+}
+
+// defaultYear is the year of the running event, otherwise the latest one in the repository
+func defaultYear(now time.Time) int {
+	if util.Today(now) != 0 {
+		return now.Year()
+	}
+	if years, err := util.Years(util.Root()); err == nil && len(years) > 0 {
+		return years[len(years)-1]
+	}
+	return util.LatestYear(now)
+}
+
+// Export sets the env vars of the settings coming from the config and .env files, unless they
+// are already set. This way the packages reading them (e.g. util.Input or the store) and the
+// commands we run (e.g. `go test`) see the same settings.
+func (v Values) Export() {
+	for _, key := range Keys {
+		value := v[key.Name]
+		if value.Source != CONFIG && value.Source != DOTENV {
+			continue
+		}
+		if os.Getenv(key.Env) == "" {
+			os.Setenv(key.Env, value.Value)
+		}
+	}
+}
@@ -2,28 +2,32 @@ package aoc
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-func GetInput(day, year int, cookie string) {
+// GetInput fetches the input of the given day and writes it into the day's directory, or into
+// $AOC_INPUTS_DIR if set. Named profiles get their own `input.<profile>.txt`, the default one
+// (empty) writes `input.txt`.
+func GetInput(day, year int, cookie, profile string) error {
 	fmt.Printf("fetching for day %d, year %d\n", day, year)
 
 	// make the request
-	url := fmt.Sprintf("https://adventofcode.com/%d/day/%d/input", year, day)
-	body := GetWithAOCCookie(url, cookie)
-
-	if strings.HasPrefix(string(body), "Puzzle inputs differ by user") {
-		panic("'Puzzle inputs differ by user' response")
+	url := fmt.Sprintf("%s/%d/day/%d/input", BaseURL(), year, day)
+	body, err := GetWithAOCCookie(url, cookie)
+	if err != nil {
+		return fmt.Errorf("fetching input: %w", err)
 	}
 
 	// write to file
-	filename := filepath.Join(util.Dirname(), "../..", fmt.Sprintf("%d/day%02d/input.txt", year, day))
-	WriteToFile(filename, body)
+	dayDir := filepath.Join(util.Root(), fmt.Sprintf("%d/day%02d", year, day))
+	filename := util.ProfileInputPath(dayDir, os.Getenv(util.InputsDirEnv), profile)
+	filename = WriteToStore(filename, body)
 
 	fmt.Println("Wrote to file: ", filename)
 
 	fmt.Println("Done!")
+	return nil
 }
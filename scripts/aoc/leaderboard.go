@@ -0,0 +1,246 @@
+package aoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// LeaderboardInterval is the minimum time between two fetches of a private leaderboard, as
+// requested by adventofcode.com
+const LeaderboardInterval = 15 * time.Minute
+
+// Leaderboard is a private leaderboard, as served by `/YYYY/leaderboard/private/view/<id>.json`
+type Leaderboard struct {
+	Event   string            `json:"event"`
+	OwnerID int               `json:"owner_id"`
+	Members map[string]Member `json:"members"`
+}
+
+// Member is a participant of a private leaderboard
+type Member struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"` // null for anonymous users
+	Stars       int    `json:"stars"`
+	LocalScore  int    `json:"local_score"`
+	GlobalScore int    `json:"global_score"`
+	LastStarTS  int64  `json:"last_star_ts"`
+	// Stars by day and part, e.g. {"1": {"1": {...}, "2": {...}}}
+	CompletionDayLevel map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// Star is a part of a puzzle solved by a member
+type Star struct {
+	GetStarTS int64 `json:"get_star_ts"`
+	StarIndex int   `json:"star_index"`
+}
+
+// DisplayName returns the name of the member, as shown by adventofcode.com
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// StarTime returns when the member got the star of the given day and part
+func (m Member) StarTime(day, part int) (time.Time, bool) {
+	star, ok := m.CompletionDayLevel[strconv.Itoa(day)][strconv.Itoa(part)]
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(star.GetStarTS, 0), true
+}
+
+// Elapsed returns the time the member took to get the star of the given day and part, counting
+// from the moment the puzzle unlocked
+func (m Member) Elapsed(year, day, part int) (time.Duration, bool) {
+	at, ok := m.StarTime(day, part)
+	if !ok {
+		return 0, false
+	}
+	return at.Sub(Unlock(year, day)), true
+}
+
+// Unlock returns when the puzzle of the given day is released: midnight EST (UTC-5)
+func Unlock(year, day int) time.Time {
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
+
+// Year returns the year of the event
+func (l *Leaderboard) Year() int {
+	year, _ := strconv.Atoi(l.Event)
+	return year
+}
+
+// Standings returns the members sorted by local score, then stars and then by who got their
+// last star first
+func (l *Leaderboard) Standings() []Member {
+	members := make([]Member, 0, len(l.Members))
+	for _, m := range l.Members {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.LocalScore != b.LocalScore {
+			return a.LocalScore > b.LocalScore
+		}
+		if a.Stars != b.Stars {
+			return a.Stars > b.Stars
+		}
+		if a.LastStarTS != b.LastStarTS {
+			return a.LastStarTS < b.LastStarTS
+		}
+		return a.ID < b.ID
+	})
+	return members
+}
+
+// Days returns the days for which any member has got a star, sorted
+func (l *Leaderboard) Days() []int {
+	seen := map[int]bool{}
+	for _, m := range l.Members {
+		for day := range m.CompletionDayLevel {
+			if d, err := strconv.Atoi(day); err == nil {
+				seen[d] = true
+			}
+		}
+	}
+	days := make([]int, 0, len(seen))
+	for day := range seen {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// ParseLeaderboard decodes the JSON of a private leaderboard
+func ParseLeaderboard(data []byte) (*Leaderboard, error) {
+	var l Leaderboard
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("parsing leaderboard: %w", err)
+	}
+	return &l, nil
+}
+
+// LeaderboardCachePath returns where the given leaderboard is cached, under the user's cache
+// directory (see os.UserCacheDir)
+func LeaderboardCachePath(year int, id string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "aoc", fmt.Sprintf("leaderboard-%d-%s.json", year, id))
+}
+
+// FetchLeaderboard gets the given private leaderboard. The response is cached, and fetched
+// again only once LeaderboardInterval has passed.
+func FetchLeaderboard(year int, id, cookie string) (*Leaderboard, error) {
+	url := fmt.Sprintf("%s/%d/leaderboard/private/view/%s.json", BaseURL(), year, id)
+	data, err := cachedFetch(LeaderboardCachePath(year, id), LeaderboardInterval, func() ([]byte, error) {
+		return GetWithAOCCookie(url, cookie)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching leaderboard: %w", err)
+	}
+	return ParseLeaderboard(data)
+}
+
+// cachedFetch returns the contents of the cache file if it's younger than maxAge. Otherwise, the
+// data is fetched and cached again.
+func cachedFetch(cache string, maxAge time.Duration, fetch func() ([]byte, error)) ([]byte, error) {
+	if info, err := os.Stat(cache); err == nil && time.Since(info.ModTime()) < maxAge {
+		if data, err := os.ReadFile(cache); err == nil {
+			return data, nil
+		}
+	}
+	data, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(cache), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("making cache directory: %w", err)
+	}
+	if err := os.WriteFile(cache, data, 0600); err != nil {
+		return nil, fmt.Errorf("writing cache: %w", err)
+	}
+	return data, nil
+}
+
+// RenderLeaderboard writes the standings, followed by the completion times of every member for
+// each day, as terminal tables or Markdown ones
+func RenderLeaderboard(w io.Writer, l *Leaderboard, markdown bool) error {
+	standings := l.Standings()
+	t := table{header: []string{"#", "NAME", "SCORE", "STARS"}}
+	for i, m := range standings {
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(i + 1), m.DisplayName(), strconv.Itoa(m.LocalScore), strconv.Itoa(m.Stars),
+		})
+	}
+	if err := t.render(w, fmt.Sprintf("Leaderboard %s", l.Event), markdown); err != nil {
+		return err
+	}
+
+	for _, day := range l.Days() {
+		t := table{header: []string{"NAME", "PART 1", "PART 2", "DELTA"}}
+		for _, m := range standings {
+			first, ok1 := m.Elapsed(l.Year(), day, 1)
+			second, ok2 := m.Elapsed(l.Year(), day, 2)
+			if !ok1 {
+				continue
+			}
+			row := []string{m.DisplayName(), formatElapsed(first), "-", "-"}
+			if ok2 {
+				row[2], row[3] = formatElapsed(second), formatElapsed(second-first)
+			}
+			t.rows = append(t.rows, row)
+		}
+		fmt.Fprintln(w)
+		if err := t.render(w, fmt.Sprintf("Day %d", day), markdown); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatElapsed formats a duration as hours, minutes and seconds, e.g. "26:03:09"
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// table is a simple table of text
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t table) render(w io.Writer, title string, markdown bool) error {
+	if markdown {
+		fmt.Fprintf(w, "## %s\n\n", title)
+		fmt.Fprintf(w, "| %s |\n", strings.Join(t.header, " | "))
+		fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(t.header)))
+		for _, row := range t.rows {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+			}
+			fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		}
+		return nil
+	}
+	fmt.Fprintln(w, title)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,105 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func loadLeaderboard(t *testing.T) *Leaderboard {
+	t.Helper()
+	data, err := os.ReadFile("testdata/leaderboard.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := ParseLeaderboard(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestLeaderboard(t *testing.T) {
+	l := loadLeaderboard(t)
+	var names []string
+	for _, m := range l.Standings() {
+		names = append(names, m.DisplayName())
+	}
+	if want := []string{"alice", "bob", "(anonymous user #789)"}; !slices.Equal(names, want) {
+		t.Errorf("Standings() = %v, want %v", names, want)
+	}
+	bob := l.Members["456"]
+	if got, ok := bob.Elapsed(l.Year(), 1, 2); !ok || got != 25*time.Hour+2*time.Minute {
+		t.Errorf("Elapsed(1, 2) = %s, %v, want 25h2m0s", got, ok)
+	}
+	if _, ok := bob.Elapsed(l.Year(), 2, 2); ok {
+		t.Error("Elapsed(2, 2) found a star bob doesn't have")
+	}
+}
+
+func TestRenderLeaderboard(t *testing.T) {
+	l := loadLeaderboard(t)
+	tests := []struct {
+		name     string
+		markdown bool
+		golden   string
+	}{
+		{"terminal", false, "testdata/leaderboard.txt"},
+		{"markdown", true, "testdata/leaderboard.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := os.ReadFile(tt.golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bytes.Buffer
+			if err := RenderLeaderboard(&got, l, tt.markdown); err != nil {
+				t.Fatalf("RenderLeaderboard() error = %v", err)
+			}
+			if got.String() != string(want) {
+				t.Errorf("RenderLeaderboard() =\n%s\nwant\n%s", got.String(), want)
+			}
+		})
+	}
+}
+
+func Test_cachedFetch(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "aoc", "leaderboard.json")
+	fetches := 0
+	fetch := func() ([]byte, error) {
+		fetches++
+		return []byte(`{"event":"2024"}`), nil
+	}
+	for range 2 {
+		if _, err := cachedFetch(cache, LeaderboardInterval, fetch); err != nil {
+			t.Fatalf("cachedFetch() error = %v", err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("fetched %d times within the interval, want 1", fetches)
+	}
+
+	stale := time.Now().Add(-LeaderboardInterval - time.Second)
+	if err := os.Chtimes(cache, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cachedFetch(cache, LeaderboardInterval, fetch); err != nil {
+		t.Fatalf("cachedFetch() error = %v", err)
+	}
+	if fetches != 2 {
+		t.Errorf("fetched %d times after the interval, want 2", fetches)
+	}
+
+	if err := os.Chtimes(cache, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	failing := func() ([]byte, error) { return nil, ErrAuth }
+	if _, err := cachedFetch(cache, LeaderboardInterval, failing); !errors.Is(err, ErrAuth) {
+		t.Errorf("cachedFetch() error = %v, want %v", err, ErrAuth)
+	}
+}
@@ -11,23 +11,35 @@ import (
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-func GetPrompt(day, year int, cookie string) {
+// GetPrompt fetches the prompt of the given day and writes it into the day's directory
+func GetPrompt(day, year int, cookie string) error {
 	fmt.Printf("fetching for day %d, year %d\n", day, year)
 
-	// make the request
-	url := fmt.Sprintf("https://adventofcode.com/%d/day/%d", year, day)
-	body := GetWithAOCCookie(url, cookie)
-
-	// parse the dang html
-	prompt := parseHTML(body)
+	prompt, err := FetchPrompt(day, year, cookie)
+	if err != nil {
+		return err
+	}
 
 	// write to file
-	filename := filepath.Join(util.Dirname(), "../../", fmt.Sprintf("%d/day%02d/prompt.md", year, day))
-	WriteToFile(filename, []byte(prompt))
+	filename := filepath.Join(util.Root(), fmt.Sprintf("%d/day%02d/prompt.md", year, day))
+	filename = WriteToStore(filename, []byte(prompt))
 
 	fmt.Println("Wrote prompt to file: ", filename)
 
 	fmt.Println("Done!")
+	return nil
+}
+
+// FetchPrompt gets the text of the puzzle of the given day, including its second part once the
+// first one is solved
+func FetchPrompt(day, year int, cookie string) (string, error) {
+	url := fmt.Sprintf("%s/%d/day/%d", BaseURL(), year, day)
+	body, err := GetWithAOCCookie(url, cookie)
+	if err != nil {
+		return "", fmt.Errorf("fetching prompt: %w", err)
+	}
+	// parse the dang html
+	return parseHTML(body), nil
 }
 
 // uses dfsHTML function once to get the class=day-desc html nodes, then parse
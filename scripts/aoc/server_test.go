@@ -0,0 +1,178 @@
+package aoc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc/aoctest"
+	"github.com/Javinator9889/aoc-2024/store"
+	"github.com/Javinator9889/aoc-2024/util"
+)
+
+// newServer starts a fake adventofcode.com used by every request of the test
+func newServer(t *testing.T) *aoctest.Server {
+	t.Helper()
+	server := aoctest.NewServer(aoctest.Fixtures)
+	t.Cleanup(server.Close)
+	t.Setenv(BaseURLEnv, server.URL)
+	return server
+}
+
+func TestGetInput(t *testing.T) {
+	newServer(t)
+	inputs := t.TempDir()
+	t.Setenv(util.InputsDirEnv, inputs)
+	t.Setenv(store.KeyEnv, "")
+
+	tests := []struct {
+		name    string
+		day     int
+		cookie  string
+		wantErr error
+	}{
+		{"ok", aoctest.Day, aoctest.Session, nil},
+		{"logged out", aoctest.Day, "expired", ErrAuth},
+		{"not unlocked", 25, aoctest.Session, ErrThrottled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GetInput(tt.day, aoctest.Year, tt.cookie, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetInput() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := os.ReadFile(filepath.Join(inputs, "2024", "day01", "input.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != aoctest.Input {
+				t.Errorf("input = %q, want %q", got, aoctest.Input)
+			}
+		})
+	}
+}
+
+func TestFetchPrompt(t *testing.T) {
+	server := newServer(t)
+	prompt, err := FetchPrompt(aoctest.Day, aoctest.Year, aoctest.Session)
+	if err != nil {
+		t.Fatalf("FetchPrompt() error = %v", err)
+	}
+	if !strings.Contains(prompt, aoctest.Title) || strings.Contains(prompt, "Part Two") {
+		t.Errorf("FetchPrompt() = %q, want only the first part", prompt)
+	}
+
+	server.Solve(aoctest.Year, aoctest.Day, 1)
+	if prompt, err = FetchPrompt(aoctest.Day, aoctest.Year, aoctest.Session); err != nil {
+		t.Fatalf("FetchPrompt() error = %v", err)
+	}
+	if !strings.Contains(prompt, "Part Two") {
+		t.Errorf("FetchPrompt() = %q, want both parts once the first one is solved", prompt)
+	}
+
+	if _, err := FetchPrompt(aoctest.Day, aoctest.Year, "expired"); !errors.Is(err, ErrAuth) {
+		t.Errorf("FetchPrompt() error = %v, want %v", err, ErrAuth)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	newServer(t)
+	// Every step depends on the previous ones, as the server keeps track of the solved parts
+	steps := []struct {
+		part    int
+		answer  string
+		verdict Verdict
+	}{
+		{1, aoctest.Answer1, CORRECT},
+		{1, aoctest.Answer1, SOLVED},
+		{2, "3", TOO_LOW},
+		{2, aoctest.Answer2, TOO_SOON},
+	}
+	for _, step := range steps {
+		got, err := Submit(aoctest.Day, aoctest.Year, step.part, step.answer, aoctest.Session)
+		if err != nil {
+			t.Fatalf("Submit(%d, %q) error = %v", step.part, step.answer, err)
+		}
+		if got.Verdict != step.verdict {
+			t.Errorf("Submit(%d, %q) = %s (%q), want %s", step.part, step.answer, got.Verdict, got.Message, step.verdict)
+		}
+		if step.verdict == TOO_SOON && (got.Wait <= 0 || got.Wait > aoctest.Throttle) {
+			t.Errorf("Submit(%d, %q) wait = %s, want up to %s", step.part, step.answer, got.Wait, aoctest.Throttle)
+		}
+	}
+
+	if _, err := Submit(aoctest.Day, aoctest.Year, 1, aoctest.Answer1, "expired"); !errors.Is(err, ErrAuth) {
+		t.Errorf("Submit() error = %v, want %v", err, ErrAuth)
+	}
+}
+
+func TestCheckAuth(t *testing.T) {
+	newServer(t)
+	tests := []struct {
+		name    string
+		cookie  string
+		want    string
+		wantErr error
+	}{
+		{"valid", aoctest.Session, aoctest.User, nil},
+		{"expired", "expired", "", ErrAuth},
+		{"missing", "", "", ErrAuth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckAuth(tt.cookie)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckAuth() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CheckAuth() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchLeaderboard(t *testing.T) {
+	server := newServer(t)
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	t.Setenv("HOME", cache)
+
+	for range 2 {
+		l, err := FetchLeaderboard(aoctest.Year, aoctest.Board, aoctest.Session)
+		if err != nil {
+			t.Fatalf("FetchLeaderboard() error = %v", err)
+		}
+		if len(l.Members) != 3 {
+			t.Errorf("FetchLeaderboard() has %d members, want 3", len(l.Members))
+		}
+	}
+	path := "/2024/leaderboard/private/view/" + aoctest.Board + ".json"
+	if got := server.Requests(path); got != 1 {
+		t.Errorf("leaderboard fetched %d times, want 1 as it is cached", got)
+	}
+}
+
+func TestFetchStats(t *testing.T) {
+	server := newServer(t)
+	server.Solve(aoctest.Year, aoctest.Day, 2)
+
+	calendar, err := FetchCalendar(aoctest.Year, aoctest.Session)
+	if err != nil {
+		t.Fatalf("FetchCalendar() error = %v", err)
+	}
+	if want := []CalendarDay{{aoctest.Day, 2}}; len(calendar) != 1 || calendar[0] != want[0] {
+		t.Errorf("FetchCalendar() = %v, want %v", calendar, want)
+	}
+	times, err := FetchPersonalTimes(aoctest.Year, aoctest.Session)
+	if err != nil {
+		t.Fatalf("FetchPersonalTimes() error = %v", err)
+	}
+	if len(times) != 2 || times[1].Rank != 64 {
+		t.Errorf("FetchPersonalTimes() = %+v, want both parts of day 1", times)
+	}
+}
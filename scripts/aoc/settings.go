@@ -0,0 +1,135 @@
+package aoc
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Javinator9889/aoc-2024/util"
+)
+
+// Settings are the effective settings shared by every tool. They are resolved by increasing
+// priority from: the defaults, the config file, the .env file, the `AOC_*` env vars and the
+// command line flags.
+type Settings struct {
+	Day, Year int    // Day is 0 out of the event when not given, see RequireDay
+	Session   string // Session cookie used to talk to adventofcode.com
+	Profile   string // Team profile, see util.Profiles
+}
+
+// Flags are the command line flags shared by every tool, see RegisterFlags
+type Flags struct {
+	fs      *flag.FlagSet
+	day     intFlag
+	year    intFlag
+	session string
+	profile string
+}
+
+// intFlag parses base 10 integers, unlike flag.Int which takes "08" as an invalid octal
+type intFlag int
+
+func (i *intFlag) String() string { return strconv.Itoa(int(*i)) }
+
+func (i *intFlag) Set(s string) error {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return errors.New("invalid number")
+	}
+	*i = intFlag(v)
+	return nil
+}
+
+// RegisterFlags adds the shared flags to the flag set. Call Resolve once the flags are parsed.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{fs: fs}
+	fs.Var(&f.day, "day", "AOC day, 1-25 or 1-12 from 2025 (default today's puzzle, if any)")
+	fs.Var(&f.year, "year", "AOC year (default the running event, or the latest year in the repository)")
+	fs.StringVar(&f.session, "cookie", "", "AOC session cookie (default $AOC_SESSION_COOKIE)")
+	fs.StringVar(&f.profile, "profile", "", "team profile, whose session is read from the config file (default $AOC_PROFILE)")
+	return f
+}
+
+func (f *Flags) isSet(name string) (set bool) {
+	f.fs.Visit(func(fl *flag.Flag) { set = set || fl.Name == name })
+	return
+}
+
+// Values looks up every setting (see Lookup) and applies the flags on top of them
+func (f *Flags) Values() (Values, error) {
+	values, err := Lookup()
+	if err != nil {
+		return nil, err
+	}
+	if f.isSet("day") {
+		values.set("day", f.day.String(), FLAG)
+	}
+	if f.isSet("year") {
+		values.set("year", f.year.String(), FLAG)
+	}
+	if f.isSet("profile") {
+		values.set("profile", f.profile, FLAG)
+	}
+	// A profile brings its own session, unless it's explicitly overridden
+	if f.isSet("cookie") {
+		values.set("session", f.session, FLAG)
+	} else if profile := values["profile"].Value; profile != "" {
+		config, err := LoadConfig(ConfigPath())
+		if err != nil {
+			return nil, fmt.Errorf("loading config: %w", err)
+		}
+		session, err := config.Session(profile)
+		if err != nil {
+			return nil, err
+		}
+		values.set("session", session, CONFIG)
+	}
+	return values, nil
+}
+
+// Resolve computes the effective settings. The session is not required here, as not every
+// command needs one: see Settings.RequireSession.
+func (f *Flags) Resolve() (Settings, error) {
+	values, err := f.Values()
+	if err != nil {
+		return Settings{}, err
+	}
+	s := Settings{Session: values["session"].Value, Profile: values["profile"].Value}
+	if err := setInt(&s.Year, values["year"], "year"); err != nil {
+		return s, err
+	}
+	// Out of December there's no default day, see Settings.RequireDay
+	if _, ok := values["day"]; !ok {
+		return s, util.CheckYear(s.Year)
+	}
+	if err := setInt(&s.Day, values["day"], "day"); err != nil {
+		return s, err
+	}
+	return s, util.CheckDay(s.Year, s.Day)
+}
+
+func setInt(dst *int, value Value, name string) error {
+	v, err := strconv.Atoi(value.Value)
+	if err != nil {
+		return fmt.Errorf("invalid %s from %s: %q", name, value.Source, value.Value)
+	}
+	*dst = v
+	return nil
+}
+
+// RequireDay fails if no day was given, which only happens when there's no puzzle today
+func (s Settings) RequireDay() error {
+	if s.Day == 0 {
+		return errors.New("no day set on flag, env var (AOC_DAY), .env or config file, and there's no puzzle today")
+	}
+	return nil
+}
+
+// RequireSession fails if there's no session cookie available
+func (s Settings) RequireSession() error {
+	if s.Session == "" {
+		return errors.New("no session cookie set on flag, env var (AOC_SESSION_COOKIE), .env or config file")
+	}
+	return nil
+}
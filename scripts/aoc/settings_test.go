@@ -0,0 +1,189 @@
+package aoc
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	t.Setenv("HOME", config)
+	for _, key := range Keys {
+		t.Setenv(key.Env, "")
+	}
+	dotenv := filepath.Join(config, ".env")
+	dotEnvPath = func() string { return dotenv }
+	t.Cleanup(func() { dotEnvPath = DotEnvPath })
+	if err := os.MkdirAll(filepath.Dir(ConfigPath()), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	content := "year = 2023\nday = 5\nsession = \"config\"\n\n[profiles.alice]\nsession = \"alice\"\n"
+	if err := os.WriteFile(ConfigPath(), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dotenv  string
+		env     map[string]string
+		args    []string
+		want    Settings
+		wantErr bool
+	}{
+		{
+			name: "config file",
+			want: Settings{Day: 5, Year: 2023, Session: "config"},
+		},
+		{
+			name: "env over config",
+			env:  map[string]string{"AOC_DAY": "6", "AOC_SESSION_COOKIE": "env"},
+			want: Settings{Day: 6, Year: 2023, Session: "env"},
+		},
+		{
+			name:   ".env over config",
+			dotenv: "AOC_YEAR=2022\nexport AOC_SESSION_COOKIE='dotenv'\n",
+			want:   Settings{Day: 5, Year: 2022, Session: "dotenv"},
+		},
+		{
+			name:   "env over .env",
+			dotenv: "AOC_YEAR=2022\nAOC_SESSION_COOKIE=dotenv\n",
+			env:    map[string]string{"AOC_SESSION_COOKIE": "env"},
+			want:   Settings{Day: 5, Year: 2022, Session: "env"},
+		},
+		{
+			name: "flags over env",
+			env:  map[string]string{"AOC_DAY": "6", "AOC_SESSION_COOKIE": "env"},
+			args: []string{"-day", "08", "-year", "2024", "-cookie", "flag"},
+			want: Settings{Day: 8, Year: 2024, Session: "flag"},
+		},
+		{
+			name: "profile session",
+			env:  map[string]string{"AOC_SESSION_COOKIE": "env"},
+			args: []string{"-profile", "alice"},
+			want: Settings{Day: 5, Year: 2023, Session: "alice", Profile: "alice"},
+		},
+		{
+			name:    "unknown profile",
+			args:    []string{"-profile", "bob"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid .env day",
+			dotenv:  "AOC_DAY=five\n",
+			wantErr: true,
+		},
+		{
+			name:    "day out of range",
+			args:    []string{"-day", "26"},
+			wantErr: true,
+		},
+		{
+			name: "12 days from 2025",
+			args: []string{"-year", "2025", "-day", "12"},
+			want: Settings{Day: 12, Year: 2025, Session: "config"},
+		},
+		{
+			name:    "day out of range from 2025",
+			args:    []string{"-year", "2025", "-day", "13"},
+			wantErr: true,
+		},
+		{
+			name:    "year before 2015",
+			args:    []string{"-year", "2014"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(dotenv, []byte(tt.dotenv), 0644); err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags := RegisterFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			got, err := flags.Resolve()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDotEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# fetched from the browser
+AOC_SESSION_COOKIE=abc123 # personal account
+export AOC_YEAR=2023
+AOC_INPUTS_DIR="/tmp/aoc inputs"
+AOC_PROFILE='alice'
+
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadDotEnv(path)
+	if err != nil {
+		t.Fatalf("LoadDotEnv() error = %v", err)
+	}
+	want := map[string]string{
+		"AOC_SESSION_COOKIE": "abc123",
+		"AOC_YEAR":           "2023",
+		"AOC_INPUTS_DIR":     "/tmp/aoc inputs",
+		"AOC_PROFILE":        "alice",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadDotEnv() = %v, want %v", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte("AOC_YEAR\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDotEnv(path); err == nil {
+		t.Error("LoadDotEnv() expected an error on a line without value")
+	}
+}
+
+func TestLookupExport(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	for _, key := range Keys {
+		t.Setenv(key.Env, "")
+	}
+	dotenv := filepath.Join(dir, ".env")
+	dotEnvPath = func() string { return dotenv }
+	t.Cleanup(func() { dotEnvPath = DotEnvPath })
+	if err := os.WriteFile(dotenv, []byte("AOC_INPUTS_DIR=/inputs\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := Lookup()
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	values.Export()
+	if got := os.Getenv("AOC_INPUTS_DIR"); got != "/inputs" {
+		t.Errorf("AOC_INPUTS_DIR = %q, want %q", got, "/inputs")
+	}
+	// The exported env var keeps its original source
+	values, err = Lookup()
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	want := Value{"/inputs", DOTENV}
+	if got := values["inputs_dir"]; got != want {
+		t.Errorf("Lookup()[inputs_dir] = %+v, want %+v", got, want)
+	}
+}
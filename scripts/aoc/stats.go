@@ -0,0 +1,172 @@
+package aoc
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/Javinator9889/aoc-2024/cast"
+)
+
+// PersonalTime is the time, rank and score of a solved part, as shown in `/YYYY/leaderboard/self`
+type PersonalTime struct {
+	Day, Part int
+	Time      time.Duration // Time since the puzzle unlocked, 0 when over 24 hours (">24h")
+	Rank      int
+	Score     int
+}
+
+// CalendarDay is a day of the calendar of a year
+type CalendarDay struct {
+	Day   int
+	Stars int // 0, 1 or 2
+}
+
+// FetchCalendar gets the stars of every unlocked day from the calendar of the year
+func FetchCalendar(year int, cookie string) ([]CalendarDay, error) {
+	body, err := GetWithAOCCookie(fmt.Sprintf("%s/%d", BaseURL(), year), cookie)
+	if err != nil {
+		return nil, fmt.Errorf("fetching calendar: %w", err)
+	}
+	return parseCalendar(body), nil
+}
+
+// FetchPersonalTimes gets the personal times of the year
+func FetchPersonalTimes(year int, cookie string) ([]PersonalTime, error) {
+	body, err := GetWithAOCCookie(fmt.Sprintf("%s/%d/leaderboard/self", BaseURL(), year), cookie)
+	if err != nil {
+		return nil, fmt.Errorf("fetching personal times: %w", err)
+	}
+	return parsePersonalTimes(body)
+}
+
+// Matches the class of the links to each day, e.g. `calendar-day6 calendar-verycomplete`
+var calendarDayRe = regexp.MustCompile(`\bcalendar-day(\d+)\b`)
+
+// parseCalendar reads the links to the days of the calendar. Days with one star are marked as
+// `calendar-complete`, and `calendar-verycomplete` when both are.
+func parseCalendar(body []byte) []CalendarDay {
+	node, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	var days []CalendarDay
+	dfsHTML(node, func(n *html.Node) []interface{} {
+		if n.Type != html.ElementNode || n.Data != "a" {
+			return nil
+		}
+		for _, attr := range n.Attr {
+			if attr.Key != "class" {
+				continue
+			}
+			m := calendarDayRe.FindStringSubmatch(attr.Val)
+			if m == nil {
+				continue
+			}
+			day := CalendarDay{Day: cast.ToInt(m[1])}
+			for _, class := range strings.Fields(attr.Val) {
+				switch class {
+				case "calendar-complete":
+					day.Stars = max(day.Stars, 1)
+				case "calendar-verycomplete":
+					day.Stars = 2
+				}
+			}
+			days = append(days, day)
+		}
+		return nil
+	})
+	sort.Slice(days, func(i, j int) bool { return days[i].Day < days[j].Day })
+	return days
+}
+
+// Matches a row of the personal times, e.g. "  6   00:10:47    432      0   01:02:03   1364      0"
+var personalTimeRe = regexp.MustCompile(`^\s*(\d+)((?:\s+\S+){3})((?:\s+\S+){3})?\s*$`)
+
+// parsePersonalTimes reads the table of personal times inside the <pre> of the page
+func parsePersonalTimes(body []byte) ([]PersonalTime, error) {
+	node, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	var text strings.Builder
+	for _, pre := range dfsHTML(node, func(n *html.Node) []interface{} {
+		if n.Type == html.ElementNode && n.Data == "pre" {
+			return []interface{}{n}
+		}
+		return nil
+	}) {
+		dfsHTML(pre.(*html.Node), func(n *html.Node) []interface{} {
+			if n.Type == html.TextNode {
+				text.WriteString(n.Data)
+			}
+			return nil
+		})
+	}
+
+	var times []PersonalTime
+	for _, line := range strings.Split(text.String(), "\n") {
+		m := personalTimeRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		day := cast.ToInt(m[1])
+		for part, columns := range m[2:] {
+			fields := strings.Fields(columns)
+			if len(fields) != 3 || fields[0] == "-" {
+				continue
+			}
+			t, err := parsePersonalTime(day, part+1, fields)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q: %w", strings.TrimSpace(line), err)
+			}
+			times = append(times, t)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		if times[i].Day != times[j].Day {
+			return times[i].Day < times[j].Day
+		}
+		return times[i].Part < times[j].Part
+	})
+	return times, nil
+}
+
+func parsePersonalTime(day, part int, fields []string) (t PersonalTime, err error) {
+	t.Day, t.Part = day, part
+	if fields[0] != ">24h" {
+		var h, m, s int
+		if _, err := fmt.Sscanf(fields[0], "%d:%d:%d", &h, &m, &s); err != nil {
+			return t, fmt.Errorf("invalid time %q", fields[0])
+		}
+		t.Time = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	}
+	if t.Rank, err = strconv.Atoi(fields[1]); err != nil {
+		return t, fmt.Errorf("invalid rank %q", fields[1])
+	}
+	if t.Score, err = strconv.Atoi(fields[2]); err != nil {
+		return t, fmt.Errorf("invalid score %q", fields[2])
+	}
+	return t, nil
+}
+
+// Stars returns the number of stars got on each day, taken from both the calendar and the
+// personal times as the calendar of some years doesn't mark the completed days
+func Stars(calendar []CalendarDay, times []PersonalTime) map[int]int {
+	stars := map[int]int{}
+	for _, day := range calendar {
+		if day.Stars > 0 {
+			stars[day.Day] = day.Stars
+		}
+	}
+	for _, t := range times {
+		stars[t.Day] = max(stars[t.Day], t.Part)
+	}
+	return stars
+}
@@ -0,0 +1,49 @@
+package aoc
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseCalendar(t *testing.T) {
+	body, err := os.ReadFile("testdata/calendar.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CalendarDay{{1, 2}, {2, 2}, {3, 1}, {4, 0}}
+	if got := parseCalendar(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCalendar() = %v, want %v", got, want)
+	}
+}
+
+func Test_parsePersonalTimes(t *testing.T) {
+	body, err := os.ReadFile("testdata/self.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parsePersonalTimes(body)
+	if err != nil {
+		t.Fatalf("parsePersonalTimes() error = %v", err)
+	}
+	want := []PersonalTime{
+		{Day: 1, Part: 1, Time: 2*time.Minute + 15*time.Second, Rank: 87, Score: 14},
+		{Day: 1, Part: 2, Time: 3*time.Minute + time.Second, Rank: 64, Score: 37},
+		{Day: 2, Part: 1, Rank: 40125},
+		{Day: 2, Part: 2, Rank: 37003},
+		{Day: 3, Part: 1, Time: 10*time.Minute + 47*time.Second, Rank: 432},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePersonalTimes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestStars(t *testing.T) {
+	calendar := []CalendarDay{{1, 2}, {2, 0}, {3, 1}}
+	times := []PersonalTime{{Day: 2, Part: 1}, {Day: 3, Part: 1}, {Day: 3, Part: 2}}
+	want := map[int]int{1: 2, 2: 1, 3: 2}
+	if got := Stars(calendar, times); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stars() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package aoc
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Verdict is the outcome of submitting an answer
+type Verdict int
+
+const (
+	UNKNOWN  Verdict = iota
+	CORRECT          // That's the right answer!
+	WRONG            // That's not the right answer
+	TOO_HIGH         // That's not the right answer; your answer is too high
+	TOO_LOW          // That's not the right answer; your answer is too low
+	TOO_SOON         // You gave an answer too recently
+	SOLVED           // You don't seem to be solving the right level (already solved)
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case CORRECT:
+		return "correct"
+	case WRONG:
+		return "wrong"
+	case TOO_HIGH:
+		return "too high"
+	case TOO_LOW:
+		return "too low"
+	case TOO_SOON:
+		return "too soon"
+	case SOLVED:
+		return "already solved"
+	}
+	return "unknown"
+}
+
+// Submission is the response to an answer
+type Submission struct {
+	Verdict Verdict
+	Wait    time.Duration // Time to wait before submitting again, if known
+	Message string        // The text of the response
+}
+
+// Submit sends the answer to the given part of the puzzle
+func Submit(day, year, part int, answer, cookie string) (Submission, error) {
+	fmt.Printf("submitting %q for day %d, year %d, part %d\n", answer, day, year, part)
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", BaseURL(), year, day)
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	body, err := PostWithAOCCookie(endpoint, form, cookie)
+	if err != nil {
+		return Submission{}, fmt.Errorf("submitting answer: %w", err)
+	}
+	return parseSubmission(body), nil
+}
+
+// Matches "You have 4m 3s left to wait", the minutes are omitted when there are none left
+var waitRe = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+
+func parseSubmission(body []byte) (s Submission) {
+	s.Message = articleText(body)
+	switch {
+	case strings.Contains(s.Message, "That's the right answer"):
+		s.Verdict = CORRECT
+	case strings.Contains(s.Message, "your answer is too high"):
+		s.Verdict = TOO_HIGH
+	case strings.Contains(s.Message, "your answer is too low"):
+		s.Verdict = TOO_LOW
+	case strings.Contains(s.Message, "That's not the right answer"):
+		s.Verdict = WRONG
+	case strings.Contains(s.Message, "You gave an answer too recently"):
+		s.Verdict = TOO_SOON
+	case strings.Contains(s.Message, "You don't seem to be solving the right level"):
+		s.Verdict = SOLVED
+	}
+	if m := waitRe.FindStringSubmatch(s.Message); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		s.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if strings.Contains(s.Message, "wait one minute") {
+		s.Wait = time.Minute
+	}
+	return
+}
+
+// articleText returns the text of the <article> nodes of the page, which hold the responses
+func articleText(htmlIn []byte) string {
+	strBuilder := strings.Builder{}
+	node, _ := html.Parse(bytes.NewReader(htmlIn))
+	articles := dfsHTML(node, func(n *html.Node) []interface{} {
+		if n.Type == html.ElementNode && n.Data == "article" {
+			return []interface{}{n}
+		}
+		return nil
+	})
+	for _, article := range articles {
+		dfsHTML(article.(*html.Node), func(n *html.Node) []interface{} {
+			if n.Type == html.TextNode {
+				strBuilder.WriteString(n.Data)
+			}
+			return nil
+		})
+	}
+	return strings.TrimSpace(strBuilder.String())
+}
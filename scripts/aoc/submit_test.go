@@ -0,0 +1,56 @@
+package aoc
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseSubmission(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{
+			name:    "correct",
+			body:    `<main><article><p>That's the right answer! You are <em>one gold star</em> closer.</p></article></main>`,
+			verdict: CORRECT,
+		},
+		{
+			name:    "too high",
+			body:    `<main><article><p>That's not the right answer; your answer is too high. Please wait one minute before trying again.</p></article></main>`,
+			verdict: TOO_HIGH,
+			wait:    time.Minute,
+		},
+		{
+			name:    "wrong",
+			body:    `<main><article><p>That's not the right answer. If you're stuck, make sure you're using the full input data.</p></article></main>`,
+			verdict: WRONG,
+		},
+		{
+			name:    "too soon",
+			body:    `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 3s left to wait.</p></article></main>`,
+			verdict: TOO_SOON,
+			wait:    4*time.Minute + 3*time.Second,
+		},
+		{
+			name:    "solved",
+			body:    `<main><article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article></main>`,
+			verdict: SOLVED,
+		},
+		{
+			name:    "unknown",
+			body:    `<main><p>Something else</p></main>`,
+			verdict: UNKNOWN,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseSubmission([]byte(tt.body))
+			if got.Verdict != tt.verdict || got.Wait != tt.wait {
+				t.Errorf("parseSubmission() = %v (wait %v), want %v (wait %v)", got.Verdict, got.Wait, tt.verdict, tt.wait)
+			}
+		})
+	}
+}
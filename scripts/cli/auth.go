@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc"
+)
+
+func auth(args []string) error {
+	fs := newFlagSet("auth")
+	flags := aoc.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || fs.Arg(0) != "check" {
+		fs.Usage()
+		return errUsage
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireSession(); err != nil {
+		return err
+	}
+	user, err := aoc.CheckAuth(s.Session)
+	if errors.Is(err, aoc.ErrAuth) {
+		return aoc.ExplainAuth(err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("session cookie is valid, logged in as", user)
+	return nil
+}
@@ -0,0 +1,124 @@
+// Package cli implements the `aoc` command, which bundles every tool of the repository behind
+// subcommands sharing the same settings resolution (see aoc.Settings).
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc"
+	"github.com/Javinator9889/aoc-2024/util"
+)
+
+// A command is an `aoc` subcommand
+type command struct {
+	usage   string // Arguments after the name, e.g. "input|prompt"
+	summary string
+	run     func(args []string) error
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"init":        {"[flags]", "fetch the prompt and input of a day, then make its skeleton", initDay},
+		"fetch":       {"[flags] input|prompt", "fetch the input or the prompt of a day", fetch},
+		"run":         {"[flags] [-- solution flags]", "run the solution of a day", runDay},
+		"test":        {"[flags] [-- go test flags]", "test the solution of a day", testDay},
+		"bench":       {"[flags] [-- go test flags]", "benchmark the solution of a day", benchDay},
+		"submit":      {"[flags] [answer]", "submit an answer, running the solution if none is given", submit},
+		"status":      {"[flags]", "show the state of every day in the repository", status},
+		"verify":      {"[flags]", "test every day in parallel, failing on any mismatch", verifyAll},
+		"store":       {"seal|unseal|key", "encrypt or decrypt every input, prompt and answers file, or make a new $AOC_INPUTS_KEY", storeFiles},
+		"stats":       {"[flags]", "compare the stars and personal times of a year with the local days", stats},
+		"leaderboard": {"[flags]", "show the standings and times of a private leaderboard", leaderboard},
+		"auth":        {"[flags] check", "check the session cookie is valid and show its user", auth},
+		"config":      {"[flags] show", "show the effective settings and where they come from", config},
+	}
+}
+
+// errUsage is returned when the command line is invalid, the usage has already been printed
+var errUsage = errors.New("invalid usage")
+
+// Main runs the `aoc` command with the given arguments (without the program name) and returns
+// the exit code
+func Main(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(os.Stderr)
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n", args[0])
+		usage(os.Stderr)
+		return 2
+	}
+	// Settings from the config and .env files are exported, so every command (and the ones they
+	// run) sees them the same way as the env vars
+	values, err := aoc.Lookup()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: %s\n", err)
+		return 1
+	}
+	values.Export()
+	err = cmd.run(args[1:])
+	if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc %s: %s\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: aoc <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'aoc <command> -h' for the flags of a command.")
+}
+
+// newFlagSet returns the flag set of a command, whose errors are reported by Main
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("aoc "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: aoc %s %s\n\n%s\n\nFlags:\n", name, commands[name].usage, commands[name].summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// root is the repository root, where the `YYYY/dayNN` directories live
+func root() string {
+	return util.Root()
+}
+
+// dayPath returns the path to the directory of a day, relative to the root
+func dayPath(day, year int) string {
+	return fmt.Sprintf("%d/day%02d", year, day)
+}
+
+// goCommand runs the go tool from the repository root, forwarding its output
+func goCommand(args ...string) error {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = root()
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
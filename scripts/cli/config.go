@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc"
+)
+
+func config(args []string) error {
+	fs := newFlagSet("config")
+	flags := aoc.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || fs.Arg(0) != "show" {
+		fs.Usage()
+		return errUsage
+	}
+	values, err := flags.Values()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-12s %s%s\n", "config file", aoc.ConfigPath(), missing(aoc.ConfigPath()))
+	fmt.Printf("%-12s %s%s\n\n", ".env", aoc.DotEnvPath(), missing(aoc.DotEnvPath()))
+	for _, key := range aoc.Keys {
+		value, ok := values[key.Name]
+		if !ok {
+			fmt.Printf("%-12s -\n", key.Name)
+			continue
+		}
+		shown := value.Value
+		if key.Secret {
+			shown = redact(shown)
+		}
+		fmt.Printf("%-12s %-20s (%s)\n", key.Name, shown, value.Source)
+	}
+	return nil
+}
+
+func missing(filename string) string {
+	if _, err := os.Stat(filename); err != nil {
+		return " (missing)"
+	}
+	return ""
+}
+
+// redact hides a secret, keeping its last characters so different secrets can be told apart
+func redact(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc"
+	"github.com/Javinator9889/aoc-2024/scripts/skeleton"
+)
+
+func fetch(args []string) error {
+	fs := newFlagSet("fetch")
+	flags := aoc.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || (fs.Arg(0) != "input" && fs.Arg(0) != "prompt") {
+		fs.Usage()
+		return errUsage
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireDay(); err != nil {
+		return err
+	}
+	if err := s.RequireSession(); err != nil {
+		return err
+	}
+	if fs.Arg(0) == "input" {
+		err = aoc.GetInput(s.Day, s.Year, s.Session, s.Profile)
+	} else {
+		err = aoc.GetPrompt(s.Day, s.Year, s.Session)
+	}
+	if err != nil {
+		return aoc.AuthHint(err, s.Session)
+	}
+	return nil
+}
+
+func initDay(args []string) error {
+	fs := newFlagSet("init")
+	flags := aoc.RegisterFlags(fs)
+	opts := skeletonFlags(fs)
+	fetch := fs.Bool("fetch", true, "fetch the prompt and input before making the skeleton")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireDay(); err != nil {
+		return err
+	}
+	options, err := opts()
+	if err != nil {
+		return err
+	}
+	switch {
+	case *fetch && options.DryRun:
+		// Nothing is requested nor written in a dry run
+		fmt.Printf("would fetch the prompt and input for %d-day%d\n", s.Year, s.Day)
+	case *fetch:
+		if err := s.RequireSession(); err != nil {
+			return fmt.Errorf("%w (use -fetch=false to only make the skeleton)", err)
+		}
+		// The prompt goes first, so the skeleton knows the puzzle title
+		if err := aoc.GetPrompt(s.Day, s.Year, s.Session); err != nil {
+			return aoc.AuthHint(err, s.Session)
+		}
+		if err := aoc.GetInput(s.Day, s.Year, s.Session, s.Profile); err != nil {
+			return aoc.AuthHint(err, s.Session)
+		}
+	}
+	skeleton.Run(s.Day, s.Year, options)
+	return nil
+}
+
+// skeletonFlags registers the skeleton flags, returning a function building its options
+func skeletonFlags(fs *flag.FlagSet) func() (skeleton.Options, error) {
+	kind := fs.String("template", skeleton.Kinds[0], "input shape parseInput is made for, see skeleton.SearchPath")
+	examplePath := fs.String("example", "", "file with the example input for the tests")
+	force := fs.Bool("force", false, "overwrite existing files")
+	dryRun := fs.Bool("dry-run", false, "print what would be written instead of writing it")
+	updateTests := fs.Bool("update-tests", false, "only regenerate main_test.go, keeping the existing tests")
+	return func() (skeleton.Options, error) {
+		opts := skeleton.Options{Kind: *kind, Force: *force, DryRun: *dryRun, UpdateTests: *updateTests}
+		if *examplePath != "" {
+			example, err := os.ReadFile(filepath.Clean(*examplePath))
+			if err != nil {
+				return opts, fmt.Errorf("reading example: %w", err)
+			}
+			opts.Example = string(example)
+		}
+		return opts, nil
+	}
+}
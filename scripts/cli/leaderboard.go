@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"errors"
+	"os"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc"
+)
+
+func leaderboard(args []string) error {
+	fs := newFlagSet("leaderboard")
+	flags := aoc.RegisterFlags(fs)
+	id := fs.String("id", os.Getenv("AOC_LEADERBOARD"), "ID of the private leaderboard (default $AOC_LEADERBOARD)")
+	markdown := fs.Bool("markdown", false, "render the tables as Markdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if *id == "" {
+		return errors.New("no leaderboard set on flag, env var (AOC_LEADERBOARD), .env or config file")
+	}
+	if err := s.RequireSession(); err != nil {
+		return err
+	}
+	l, err := aoc.FetchLeaderboard(s.Year, *id, s.Session)
+	if err != nil {
+		return aoc.AuthHint(err, s.Session)
+	}
+	return aoc.RenderLeaderboard(os.Stdout, l, *markdown)
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc"
+	"github.com/Javinator9889/aoc-2024/util"
+)
+
+// solutionArgs returns the `go run` arguments to run a part of the solution. Profiles use their
+// own input unless another one is given.
+func solutionArgs(s aoc.Settings, part int, input string, extra []string) []string {
+	args := []string{"run", "./" + dayPath(s.Day, s.Year), "-part", strconv.Itoa(part)}
+	if input == "" && s.Profile != "" {
+		dayDir := filepath.Join(root(), dayPath(s.Day, s.Year))
+		input = util.ProfileInputPath(dayDir, os.Getenv(util.InputsDirEnv), s.Profile)
+	}
+	if input != "" {
+		args = append(args, "-input", input)
+	}
+	return append(args, extra...)
+}
+
+func runDay(args []string) error {
+	fs := newFlagSet("run")
+	flags := aoc.RegisterFlags(fs)
+	part := fs.Int("part", 0, "part to run, both if 0")
+	input := fs.String("input", "", "file with the puzzle input, \"-\" reads it from stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireDay(); err != nil {
+		return err
+	}
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+	for _, p := range parts {
+		if err := goCommand(solutionArgs(s, p, *input, fs.Args())...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testDay(args []string) error {
+	fs := newFlagSet("test")
+	flags := aoc.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireDay(); err != nil {
+		return err
+	}
+	return goCommand(append(append([]string{"test"}, fs.Args()...), "./"+dayPath(s.Day, s.Year)+"/...")...)
+}
+
+func benchDay(args []string) error {
+	fs := newFlagSet("bench")
+	flags := aoc.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireDay(); err != nil {
+		return err
+	}
+	bench := []string{"test", "-run", "^$", "-bench", ".", "-benchmem"}
+	return goCommand(append(append(bench, fs.Args()...), "./"+dayPath(s.Day, s.Year)+"/...")...)
+}
+
+func submit(args []string) error {
+	fs := newFlagSet("submit")
+	flags := aoc.RegisterFlags(fs)
+	part := fs.Int("part", 1, "part to submit")
+	input := fs.String("input", "", "file with the puzzle input used to compute the answer")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errUsage
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireDay(); err != nil {
+		return err
+	}
+	if err := s.RequireSession(); err != nil {
+		return err
+	}
+
+	answer := fs.Arg(0)
+	if answer == "" {
+		if answer, err = solve(s, *part, *input); err != nil {
+			return err
+		}
+	}
+	res, err := aoc.Submit(s.Day, s.Year, *part, answer, s.Session)
+	if err != nil {
+		return aoc.AuthHint(err, s.Session)
+	}
+	fmt.Println(res.Message)
+	switch res.Verdict {
+	case aoc.CORRECT:
+		dayDir := filepath.Join(root(), dayPath(s.Day, s.Year))
+		written, err := util.WriteAnswer(dayDir, os.Getenv(util.InputsDirEnv), s.Profile, *part, answer)
+		if err != nil {
+			return fmt.Errorf("recording answer: %w", err)
+		}
+		fmt.Println("Recorded answer in", written)
+	case aoc.SOLVED:
+	default:
+		if res.Wait > 0 {
+			return fmt.Errorf("answer %s, wait %s before trying again", res.Verdict, res.Wait)
+		}
+		return fmt.Errorf("answer %s", res.Verdict)
+	}
+	return nil
+}
+
+// solve runs the solution and returns its answer, the value after the "Output:" it prints
+func solve(s aoc.Settings, part int, input string) (string, error) {
+	cmd := exec.Command("go", solutionArgs(s, part, input, nil)...)
+	cmd.Dir = root()
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running solution: %w", err)
+	}
+	answer := ""
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(scanner.Text(), "Output:"); ok {
+			answer = strings.TrimSpace(v)
+		}
+	}
+	if answer == "" {
+		return "", errors.New("the solution printed no \"Output:\"")
+	}
+	return answer, nil
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Javinator9889/aoc-2024/scripts/aoc"
+	"github.com/Javinator9889/aoc-2024/scripts/verify"
+)
+
+func stats(args []string) error {
+	fs := newFlagSet("stats")
+	flags := aoc.RegisterFlags(fs)
+	test := fs.Bool("test", true, "run the tests of the local days, otherwise they only need to exist")
+	workers := fs.Int("j", runtime.NumCPU(), "number of days tested in parallel")
+	timeout := fs.Duration("timeout", 10*time.Minute, "maximum time spent testing a single day")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+	s, err := flags.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := s.RequireSession(); err != nil {
+		return err
+	}
+
+	calendar, err := aoc.FetchCalendar(s.Year, s.Session)
+	if err != nil {
+		return aoc.AuthHint(err, s.Session)
+	}
+	times, err := aoc.FetchPersonalTimes(s.Year, s.Session)
+	if err != nil {
+		return aoc.AuthHint(err, s.Session)
+	}
+	stars := aoc.Stars(calendar, times)
+
+	days, err := discover(s.Year)
+	if err != nil {
+		return err
+	}
+	local := map[int]string{}
+	if *test {
+		results := verify.Run(context.Background(), root(), days, verify.Options{Workers: *workers, Timeout: *timeout})
+		for _, r := range results {
+			local[r.Day.Day] = r.Status.String()
+		}
+	} else {
+		for _, d := range days {
+			local[d.Day] = "EXISTS"
+		}
+	}
+
+	byPart := map[[2]int]aoc.PersonalTime{}
+	for _, t := range times {
+		byPart[[2]int{t.Day, t.Part}] = t
+	}
+	var all []int
+	for day := range stars {
+		all = append(all, day)
+	}
+	for day := range local {
+		if _, ok := stars[day]; !ok {
+			all = append(all, day)
+		}
+	}
+	sort.Ints(all)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tSTARS\tPART 1\tRANK\tSCORE\tPART 2\tRANK\tSCORE\tLOCAL")
+	var missingLocally, missingRemotely []string
+	for _, day := range all {
+		row := []string{strconv.Itoa(day), strings.Repeat("*", stars[day])}
+		for part := 1; part <= 2; part++ {
+			t, ok := byPart[[2]int{day, part}]
+			switch {
+			case !ok:
+				row = append(row, "-", "-", "-")
+			case t.Time == 0:
+				row = append(row, ">24h", strconv.Itoa(t.Rank), strconv.Itoa(t.Score))
+			default:
+				row = append(row, t.Time.String(), strconv.Itoa(t.Rank), strconv.Itoa(t.Score))
+			}
+		}
+		state, ok := local[day]
+		if !ok {
+			state = "MISSING"
+		}
+		fmt.Fprintln(tw, strings.Join(append(row, state), "\t"))
+
+		solvedLocally := state == verify.PASS.String() || state == "EXISTS"
+		if stars[day] > 0 && !solvedLocally {
+			missingLocally = append(missingLocally, strconv.Itoa(day))
+		}
+		if stars[day] == 0 && solvedLocally {
+			missingRemotely = append(missingRemotely, strconv.Itoa(day))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(missingLocally) > 0 {
+		fmt.Printf("\nsolved remotely but missing or failing locally: %s\n", strings.Join(missingLocally, ", "))
+	}
+	if len(missingRemotely) > 0 {
+		fmt.Printf("\nsolved locally but without stars remotely: %s\n", strings.Join(missingRemotely, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Javinator9889/aoc-2024/scripts/verify"
+	"github.com/Javinator9889/aoc-2024/store"
+	"github.com/Javinator9889/aoc-2024/util"
+)
+
+// exists reports whether the file exists, either as plaintext or encrypted
+func exists(filename string) bool {
+	for _, name := range []string{filename, filename + store.Ext} {
+		if _, err := os.Stat(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func mark(ok bool) string {
+	if ok {
+		return "x"
+	}
+	return "-"
+}
+
+func status(args []string) error {
+	fs := newFlagSet("status")
+	year := fs.Int("year", 0, "only show the given AOC year, 0 shows all of them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	days, err := discover(*year)
+	if err != nil {
+		return err
+	}
+	inputsDir := os.Getenv(util.InputsDirEnv)
+	fmt.Printf("%-10s  %-4s  %-5s  %-5s  %-6s  %s\n", "DAY", "MAIN", "TESTS", "INPUT", "PROMPT", "PROFILES")
+	for _, d := range days {
+		dayDir := filepath.Join(root(), d.Dir)
+		fmt.Printf(
+			"%-10s  %-4s  %-5s  %-5s  %-6s  %s\n",
+			d,
+			mark(exists(filepath.Join(dayDir, "main.go"))),
+			mark(exists(filepath.Join(dayDir, "main_test.go"))),
+			mark(exists(util.InputPath(dayDir, inputsDir))),
+			mark(exists(filepath.Join(dayDir, "prompt.md"))),
+			strings.Join(util.Profiles(dayDir, inputsDir), ","),
+		)
+	}
+	return nil
+}
+
+// discover lists the days in the repository, only the ones of year unless it is 0
+func discover(year int) ([]verify.Day, error) {
+	days, err := verify.Discover(root())
+	if err != nil {
+		return nil, fmt.Errorf("discovering days: %w", err)
+	}
+	if year == 0 {
+		return days, nil
+	}
+	filtered := days[:0]
+	for _, d := range days {
+		if d.Year == year {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Javinator9889/aoc-2024/store"
+)
+
+func storeFiles(args []string) error {
+	fs := newFlagSet("store")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || (fs.Arg(0) != "seal" && fs.Arg(0) != "unseal" && fs.Arg(0) != "key") {
+		fs.Usage()
+		return errUsage
+	}
+	if fs.Arg(0) == "key" {
+		key, err := store.NewKey()
+		if err != nil {
+			return fmt.Errorf("making key: %w", err)
+		}
+		fmt.Println(key)
+		return nil
+	}
+	unseal := fs.Arg(0) == "unseal"
+	failed := false
+	// The inputs and answers of every profile too, see util.InputName and util.AnswersName
+	for _, name := range []string{"input*.txt", "prompt.md", "answers*.txt"} {
+		pattern := filepath.Join(root(), "[0-9][0-9][0-9][0-9]", "day[0-9][0-9]", name)
+		if unseal {
+			pattern += store.Ext
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("looking for files: %w", err)
+		}
+		for _, match := range matches {
+			if unseal {
+				match = match[:len(match)-len(store.Ext)]
+				err = store.Unseal(match)
+			} else {
+				err = store.Seal(match)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", match, err)
+				failed = true
+				continue
+			}
+			fmt.Println("Done:", match)
+		}
+	}
+	if failed {
+		return errors.New("some files could not be processed")
+	}
+	return nil
+}
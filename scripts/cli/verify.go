@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/Javinator9889/aoc-2024/scripts/verify"
+)
+
+func verifyAll(args []string) error {
+	fs := newFlagSet("verify")
+	workers := fs.Int("j", runtime.NumCPU(), "number of days tested in parallel")
+	year := fs.Int("year", 0, "only test the given AOC year, 0 tests all of them")
+	timeout := fs.Duration("timeout", 10*time.Minute, "maximum time spent testing a single day")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	days, err := discover(*year)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("no days found in %s", root())
+	}
+
+	results := verify.Run(context.Background(), root(), days, verify.Options{Workers: *workers, Timeout: *timeout})
+	verify.Print(os.Stdout, results)
+	if verify.Failed(results) {
+		return errors.New("some days failed")
+	}
+	return nil
+}
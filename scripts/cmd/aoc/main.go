@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"github.com/Javinator9889/aoc-2024/scripts/cli"
+)
+
+func main() {
+	os.Exit(cli.Main(os.Args[1:]))
+}
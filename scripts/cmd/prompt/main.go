@@ -1,8 +0,0 @@
-package main
-
-import "github.com/Javinator9889/aoc-2024/scripts/aoc"
-
-func main() {
-	day, year, cookie := aoc.ParseFlags()
-	aoc.GetPrompt(day, year, cookie)
-}
@@ -1,16 +0,0 @@
-package main
-
-import (
-	"flag"
-	"time"
-
-	"github.com/Javinator9889/aoc-2024/scripts/skeleton"
-)
-
-func main() {
-	today := time.Now()
-	day := flag.Int("day", today.Day(), "day number to fetch, 1-25")
-	year := flag.Int("year", today.Year(), "AOC year")
-	flag.Parse()
-	skeleton.Run(*day, *year)
-}
@@ -0,0 +1,143 @@
+package skeleton
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+)
+
+// mergeTests adds the declarations of the scaffold missing from the existing test file, keeping
+// the existing ones (hand-written tests, filled-in examples...) untouched. The imports needed by
+// the added declarations are merged too.
+func mergeTests(existing, scaffold []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	old, err := parser.ParseFile(fset, "existing", existing, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	gen, err := parser.ParseFile(fset, "scaffold", scaffold, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := map[string]bool{}
+	for _, decl := range old.Decls {
+		for _, name := range declNames(decl) {
+			declared[name] = true
+		}
+	}
+	imported := map[string]bool{}
+	for _, imp := range old.Imports {
+		imported[imp.Path.Value] = true
+	}
+
+	var added bytes.Buffer
+	used := map[string]bool{}
+	for _, decl := range gen.Decls {
+		names := declNames(decl)
+		if len(names) == 0 || declared[names[0]] {
+			continue
+		}
+		start := decl.Pos()
+		if doc := declDoc(decl); doc != nil {
+			start = doc.Pos()
+		}
+		added.WriteString("\n")
+		added.Write(scaffold[fset.Position(start).Offset:fset.Position(decl.End()).Offset])
+		added.WriteString("\n")
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					used[id.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	if added.Len() == 0 {
+		return existing, nil
+	}
+
+	var missing []string
+	for _, imp := range gen.Imports {
+		pkg, _ := strconv.Unquote(imp.Path.Value)
+		if !imported[imp.Path.Value] && used[path.Base(pkg)] {
+			missing = append(missing, imp.Path.Value)
+		}
+	}
+
+	merged := bytes.Clone(existing)
+	if len(missing) > 0 {
+		merged = addImports(fset, old, merged, missing)
+	}
+	merged = append(merged, added.Bytes()...)
+	return format.Source(merged)
+}
+
+// addImports inserts the import paths into the source of file
+func addImports(fset *token.FileSet, file *ast.File, src []byte, paths []string) []byte {
+	var specs bytes.Buffer
+	for _, p := range paths {
+		specs.WriteString("\n\t" + p)
+	}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT || !gd.Lparen.IsValid() {
+			continue
+		}
+		at := fset.Position(gd.Rparen).Offset
+		return append(src[:at:at], append(append(specs.Bytes(), '\n'), src[at:]...)...)
+	}
+	// No grouped import declaration, add one right after the package clause
+	at := fset.Position(file.Name.End()).Offset
+	block := append([]byte("\n\nimport ("), append(specs.Bytes(), []byte("\n)")...)...)
+	return append(src[:at:at], append(block, src[at:]...)...)
+}
+
+func declNames(decl ast.Decl) (names []string) {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		name := d.Name.Name
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			name = recvName(d.Recv.List[0].Type) + "." + name
+		}
+		return []string{name}
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					names = append(names, n.Name)
+				}
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			}
+		}
+	}
+	return
+}
+
+// recvName returns the name of a receiver type, ignoring pointers
+func recvName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return recvName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
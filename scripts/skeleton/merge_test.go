@@ -0,0 +1,67 @@
+package skeleton
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_mergeTests(t *testing.T) {
+	existing := `package main
+
+import "testing"
+
+var example = ` + "`1 2`" + `
+
+// Hand-written, must be kept
+func Test_part1(t *testing.T) {
+	if got := part1(example); got != 3 {
+		t.Errorf("part1() = %v, want 3", got)
+	}
+}
+`
+	scaffold := `package main
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/Javinator9889/aoc-2024/harness"
+)
+
+var example = ""
+
+func Test_part1(t *testing.T) {
+	slog.SetLogLoggerLevel(slog.LevelDebug)
+}
+
+// Runs every profile
+func Test_profiles(t *testing.T) {
+	harness.Profiles(t, part1, part2)
+}
+`
+	got, err := mergeTests([]byte(existing), []byte(scaffold))
+	if err != nil {
+		t.Fatalf("mergeTests() error = %v", err)
+	}
+	merged := string(got)
+	for _, want := range []string{
+		"// Hand-written, must be kept",
+		"got != 3",
+		"// Runs every profile\nfunc Test_profiles(t *testing.T) {",
+		`"github.com/Javinator9889/aoc-2024/harness"`,
+	} {
+		if !strings.Contains(merged, want) {
+			t.Errorf("mergeTests() is missing %q:\n%s", want, merged)
+		}
+	}
+	for _, unwanted := range []string{`var example = ""`, `"log/slog"`, "SetLogLoggerLevel"} {
+		if strings.Contains(merged, unwanted) {
+			t.Errorf("mergeTests() should not contain %q:\n%s", unwanted, merged)
+		}
+	}
+
+	// Nothing to add, the file is kept as is
+	if got, err := mergeTests(got, []byte(scaffold)); err != nil || string(got) != merged {
+		t.Errorf("mergeTests() of an up to date file changed it:\n%s", got)
+	}
+}
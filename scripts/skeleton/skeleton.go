@@ -2,57 +2,201 @@
 package skeleton
 
 import (
-	"embed"
+	"bytes"
 	"fmt"
+	"go/format"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"text/template"
 
+	"github.com/Javinator9889/aoc-2024/store"
 	"github.com/Javinator9889/aoc-2024/util"
 )
 
-//go:embed tmpls/*.go
-var fs embed.FS
+// Kinds are the embedded input shapes the generated parseInput can be pre-wired for. More of
+// them can be added to the search path, see SearchPath.
+var Kinds = []string{"ints", "lines", "grid", "blocks"}
+
+// Data is passed to the templates when rendering them
+type Data struct {
+	Day, Year int
+	Title     string // Puzzle title, e.g. "Day 6: Guard Gallivant"
+	Example   string // Example input used by the generated tests
+	Kind      string // The shape of the input, see Kinds
+}
+
+// Options tweak the generated skeleton
+type Options struct {
+	Kind        string // The shape of the input, see Kinds. Defaults to "ints"
+	Example     string // Example input for the tests, optional
+	Force       bool   // Overwrite existing files
+	DryRun      bool   // Print the files instead of writing them
+	UpdateTests bool   // Only regenerate main_test.go, keeping the existing tests
+}
+
+var funcs = template.FuncMap{
+	// Renders a string as a Go literal, preferring raw strings as the examples are multi-line
+	"rawString": func(s string) string {
+		if strings.Contains(s, "`") {
+			return strconv.Quote(s)
+		}
+		return "`" + s + "`"
+	},
+}
 
 // Run makes a skeleton main.go and main_test.go file for the given day and year
-func Run(day, year int) {
-	if day > 25 || day <= 0 {
-		log.Fatalf("invalid -day value, must be 1 through 25, got %v", day)
+func Run(day, year int, opts Options) {
+	if err := util.CheckDay(year, day); err != nil {
+		log.Fatalf("invalid day: %s", err)
 	}
 
-	if year < 2015 {
-		log.Fatalf("year is before 2015: %d", year)
+	if opts.Kind == "" {
+		opts.Kind = Kinds[0]
 	}
-
-	ts, err := template.ParseFS(fs, "tmpls/*.go")
+	ts, names, err := loadTemplates(opts.Kind, SearchPath())
 	if err != nil {
-		log.Fatalf("parsing tmpls directory: %s", err)
+		log.Fatalf("parsing templates: %s", err)
 	}
 
-	mainFilename := filepath.Join(util.Dirname(), "../../", fmt.Sprintf("%d/day%02d/main.go", year, day))
-	testFilename := filepath.Join(util.Dirname(), "../../", fmt.Sprintf("%d/day%02d/main_test.go", year, day))
+	dir := filepath.Join(util.Root(), fmt.Sprintf("%d/day%02d", year, day))
+	testFilename := filepath.Join(dir, strings.TrimSuffix(testTmpl, ".tmpl"))
 
-	err = os.MkdirAll(filepath.Dir(mainFilename), os.ModePerm)
+	data := Data{
+		Day:     day,
+		Year:    year,
+		Title:   readTitle(filepath.Join(dir, "prompt.md"), day),
+		Example: strings.TrimRight(opts.Example, "\n"),
+		Kind:    opts.Kind,
+	}
+	// Render everything before writing, so a failure doesn't leave a half-generated day behind
+	var files []file
+	for _, name := range names {
+		if opts.UpdateTests && name != testTmpl {
+			continue
+		}
+		files = append(files, file{filepath.Join(dir, strings.TrimSuffix(name, ".tmpl")), name, nil})
+	}
+	for i := range files {
+		source, err := render(ts, files[i].tmpl, data, strings.HasSuffix(files[i].name, ".go"))
+		if err != nil {
+			log.Fatalf("rendering %s: %s", files[i].tmpl, err)
+		}
+		files[i].contents = source
+	}
+
+	if opts.UpdateTests {
+		existing, err := os.ReadFile(testFilename)
+		if err == nil {
+			if files[0].contents, err = mergeTests(existing, files[0].contents); err != nil {
+				log.Fatalf("updating %s: %s", testFilename, err)
+			}
+		} else if !os.IsNotExist(err) {
+			log.Fatalf("reading %s: %s", testFilename, err)
+		}
+	} else if !opts.Force {
+		for _, f := range files {
+			ensureNotOverwriting(f.name)
+		}
+	}
+
+	if opts.DryRun {
+		for _, f := range files {
+			fmt.Printf("would write %s:\n%s\n", f.name, f.contents)
+		}
+		return
+	}
+
+	err = os.MkdirAll(dir, os.ModePerm)
 	if err != nil {
 		log.Fatalf("making directory: %s", err)
 	}
+	if err := writeAll(files); err != nil {
+		log.Fatalf("creating files: %v", err)
+	}
+	fmt.Printf("templates made for %d-day%d\n", year, day)
+}
 
-	ensureNotOverwriting(mainFilename)
-	ensureNotOverwriting(testFilename)
+// A file to be generated from a template
+type file struct {
+	name     string
+	tmpl     string
+	contents []byte
+}
 
-	mainFile, err := os.Create(mainFilename)
-	if err != nil {
-		log.Fatalf("creating main.go file: %v", err)
+// writeAll writes every file into a temporary file next to it, and renames them only once all of
+// them are complete. If any write fails, the temporary files are removed and none is renamed, so
+// the day is never left half-generated.
+func writeAll(files []file) error {
+	tmps := make([]string, 0, len(files))
+	defer func() {
+		for _, tmp := range tmps {
+			os.Remove(tmp)
+		}
+	}()
+	for _, f := range files {
+		tmp, err := writeTemp(f.name, f.contents)
+		if tmp != "" {
+			tmps = append(tmps, tmp)
+		}
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", filepath.Base(f.name), err)
+		}
 	}
-	testFile, err := os.Create(testFilename)
+	for i, f := range files {
+		if err := os.Rename(tmps[i], f.name); err != nil {
+			return fmt.Errorf("renaming %s: %w", filepath.Base(f.name), err)
+		}
+	}
+	return nil
+}
+
+// writeTemp writes the contents into a temporary file next to filename, returning its name once
+// it's created even if writing fails
+func writeTemp(filename string, contents []byte) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*")
 	if err != nil {
-		log.Fatalf("creating main_test.go file: %v", err)
+		return "", err
+	}
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return tmp.Name(), err
 	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return tmp.Name(), err
+	}
+	return tmp.Name(), tmp.Close()
+}
 
-	ts.ExecuteTemplate(mainFile, "main.go", nil)
-	ts.ExecuteTemplate(testFile, "main_test.go", nil)
-	fmt.Printf("templates made for %d-day%d\n", year, day)
+// render executes the template, formatting the result if it is Go source
+func render(ts *template.Template, name string, data Data, gosrc bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := ts.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+	if !gosrc {
+		return buf.Bytes(), nil
+	}
+	return format.Source(buf.Bytes())
+}
+
+// readTitle gets the puzzle title from the first non-blank line of the prompt (e.g.
+// "--- Day 6: Guard Gallivant ---"), if it was already fetched
+func readTitle(promptFilename string, day int) string {
+	title := fmt.Sprintf("Day %d", day)
+	prompt, err := store.ReadFile(promptFilename)
+	if err != nil {
+		return title
+	}
+	for _, line := range strings.Split(string(prompt), "\n") {
+		if line = strings.TrimSpace(strings.Trim(line, "- ")); line != "" {
+			return line
+		}
+	}
+	return title
 }
 
 func ensureNotOverwriting(filename string) {
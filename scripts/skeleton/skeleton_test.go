@@ -0,0 +1,83 @@
+package skeleton
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_readTitle(t *testing.T) {
+	dir := t.TempDir()
+	prompts := map[string]string{
+		"fetched.md":   "\n--- Day 6: Guard Gallivant ---\nThe Historians use their fancy device again...\n",
+		"first.md":     "--- Day 7: Bridge Repair ---\n",
+		"blank.md":     "\n\n",
+		"dashes.md":    "\n---\n",
+		"separated.md": "\n  \n--- Day 9: Disk Fragmenter ---",
+	}
+	for name, content := range prompts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name     string
+		filename string
+		day      int
+		want     string
+	}{
+		{"actual prompt", filepath.Join("..", "..", "2024", "day06", "prompt.md"), 6, "Day 6: Guard Gallivant"},
+		{"fetched", filepath.Join(dir, "fetched.md"), 6, "Day 6: Guard Gallivant"},
+		{"title first", filepath.Join(dir, "first.md"), 7, "Day 7: Bridge Repair"},
+		{"blank lines", filepath.Join(dir, "separated.md"), 9, "Day 9: Disk Fragmenter"},
+		{"blank", filepath.Join(dir, "blank.md"), 1, "Day 1"},
+		{"only dashes", filepath.Join(dir, "dashes.md"), 2, "Day 2"},
+		{"missing", filepath.Join(dir, "missing.md"), 3, "Day 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readTitle(tt.filename, tt.day); got != tt.want {
+				t.Errorf("readTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeAll(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(existing, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files := []file{
+		{name: existing, contents: []byte("new")},
+		{name: filepath.Join(dir, "main_test.go"), contents: []byte("test")},
+		// Its directory is missing, so it can't be written
+		{name: filepath.Join(dir, "missing", "notes.md"), contents: []byte("notes")},
+	}
+	if err := writeAll(files); err == nil {
+		t.Fatalf("writeAll() should fail")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "main.go" {
+		t.Errorf("writeAll() left %v, want only main.go", entries)
+	}
+	if got, _ := os.ReadFile(existing); string(got) != "old" {
+		t.Errorf("writeAll() overwrote main.go with %q", got)
+	}
+
+	if err := writeAll(files[:2]); err != nil {
+		t.Fatalf("writeAll() error = %v", err)
+	}
+	for _, f := range files[:2] {
+		if got, _ := os.ReadFile(f.name); string(got) != string(f.contents) {
+			t.Errorf("writeAll() wrote %q into %s, want %q", got, f.name, f.contents)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("writeAll() left %d files, want 2", len(entries))
+	}
+}
@@ -0,0 +1,122 @@
+package skeleton
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed tmpls/*.tmpl tmpls/kinds/*.tmpl
+var tmplsFS embed.FS
+
+// TemplatesEnv is the env var with a list of directories (separated as $PATH) whose templates
+// override the embedded ones
+const TemplatesEnv = "AOC_TEMPLATES"
+
+// The templates every skeleton is made of. Any other top-level template found in the search path
+// (e.g. `notes.md.tmpl`) is rendered alongside them, dropping the `.tmpl` extension.
+const (
+	mainTmpl = "main.go.tmpl"
+	testTmpl = "main_test.go.tmpl"
+)
+
+// SearchPath returns the directories looked up for templates, by decreasing priority: every
+// directory in $AOC_TEMPLATES, then `~/.config/aoc/tmpls`. The embedded templates come last.
+// The directories mirror the embedded `tmpls` one, with the input kinds living in `kinds/`.
+func SearchPath() (dirs []string) {
+	if env := os.Getenv(TemplatesEnv); env != "" {
+		dirs = append(dirs, filepath.SplitList(env)...)
+	}
+	if config, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(config, "aoc", "tmpls"))
+	}
+	return
+}
+
+// layers returns the template file systems by increasing priority, so later ones override
+func layers(dirs []string) []fs.FS {
+	embedded, err := fs.Sub(tmplsFS, "tmpls")
+	if err != nil {
+		panic(err)
+	}
+	fss := []fs.FS{embedded}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if info, err := os.Stat(dirs[i]); err == nil && info.IsDir() {
+			fss = append(fss, os.DirFS(dirs[i]))
+		}
+	}
+	return fss
+}
+
+// lookup finds the templates matching pattern, mapping each name to the file system it is read
+// from after applying the overrides
+func lookup(fss []fs.FS, pattern string) map[string]fs.FS {
+	found := map[string]fs.FS{}
+	for _, fsys := range fss {
+		matches, _ := fs.Glob(fsys, pattern)
+		for _, match := range matches {
+			found[match] = fsys
+		}
+	}
+	return found
+}
+
+// AvailableKinds lists the input kinds found in the search path, including the embedded ones
+func AvailableKinds(dirs []string) (kinds []string) {
+	for name := range lookup(layers(dirs), "kinds/*.tmpl") {
+		kinds = append(kinds, strings.TrimSuffix(path.Base(name), ".tmpl"))
+	}
+	// Keep the embedded ones first, in their usual order
+	sort.Slice(kinds, func(i, j int) bool {
+		a, b := slices.Index(Kinds, kinds[i]), slices.Index(Kinds, kinds[j])
+		if a == -1 && b == -1 {
+			return kinds[i] < kinds[j]
+		}
+		return b == -1 || (a != -1 && a < b)
+	})
+	return
+}
+
+// loadTemplates parses the templates for the given input kind from the search path. Returns the
+// templates along with the names of the ones producing a file.
+func loadTemplates(kind string, dirs []string) (*template.Template, []string, error) {
+	fss := layers(dirs)
+	kinds := lookup(fss, "kinds/*.tmpl")
+	kindName := "kinds/" + kind + ".tmpl"
+	if _, ok := kinds[kindName]; !ok {
+		return nil, nil, fmt.Errorf("invalid template kind %q, must be one of %v", kind, AvailableKinds(dirs))
+	}
+
+	files := lookup(fss, "*.tmpl")
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if name != mainTmpl && name != testTmpl {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	names = append([]string{mainTmpl, testTmpl}, names...)
+
+	ts := template.New("").Funcs(funcs)
+	sources := map[string]fs.FS{kindName: kinds[kindName]}
+	for name, fsys := range files {
+		sources[name] = fsys
+	}
+	for name, fsys := range sources {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := ts.New(name).Parse(string(content)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return ts, names, nil
+}
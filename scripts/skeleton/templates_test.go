@@ -0,0 +1,64 @@
+package skeleton
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_loadTemplates(t *testing.T) {
+	personal, team := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		filepath.Join(personal, "notes.md.tmpl"):     "# {{ .Title }}\n",
+		filepath.Join(team, "notes.md.tmpl"):         "overridden\n",
+		filepath.Join(team, "parse.go.tmpl"):         "package main\n",
+		filepath.Join(team, "kinds", "csv.tmpl"):     `{{ define "imports" }}{{ end }}{{ define "parse" }}// csv{{ end }}`,
+		filepath.Join(personal, "main_test.go.tmpl"): "package main\n\n// {{ .Year }}\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dirs := []string{personal, team, filepath.Join(t.TempDir(), "missing")}
+
+	if kinds := AvailableKinds(dirs); !slices.Equal(kinds, append(slices.Clone(Kinds), "csv")) {
+		t.Errorf("AvailableKinds() = %v", kinds)
+	}
+	if _, _, err := loadTemplates("unknown", dirs); err == nil {
+		t.Errorf("loadTemplates() of an unknown kind should fail")
+	}
+
+	ts, names, err := loadTemplates("csv", dirs)
+	if err != nil {
+		t.Fatalf("loadTemplates() error = %v", err)
+	}
+	if want := []string{mainTmpl, testTmpl, "notes.md.tmpl", "parse.go.tmpl"}; !slices.Equal(names, want) {
+		t.Errorf("loadTemplates() names = %v, want %v", names, want)
+	}
+	data := Data{Day: 6, Year: 2024, Title: "Day 6: Guard Gallivant", Kind: "csv"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"notes.md.tmpl", "# Day 6: Guard Gallivant\n"},
+		{testTmpl, "package main\n\n// 2024\n"},
+		{mainTmpl, "// csv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(ts, tt.name, data, strings.HasSuffix(tt.name, ".go.tmpl"))
+			if err != nil {
+				t.Fatalf("render() error = %v", err)
+			}
+			if !strings.Contains(string(got), tt.want) {
+				t.Errorf("render() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+// Package verify runs the tests of every solved day and reports the results as a matrix.
+package verify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Javinator9889/aoc-2024/cast"
+)
+
+// Status is the outcome of running the tests of a single day
+type Status int
+
+const (
+	PASS Status = iota
+	FAIL
+	SKIP
+)
+
+func (s Status) String() string {
+	switch s {
+	case PASS:
+		return "PASS"
+	case FAIL:
+		return "FAIL"
+	case SKIP:
+		return "SKIP"
+	}
+	return "????"
+}
+
+// Day is a `YYYY/dayNN` directory found in the repository
+type Day struct {
+	Year, Day int
+	Dir       string // Path relative to the repository root
+}
+
+func (d Day) String() string {
+	return fmt.Sprintf("%d/day%02d", d.Year, d.Day)
+}
+
+// Result holds the outcome of running the tests of a day
+type Result struct {
+	Day
+	Status   Status
+	Passed   int
+	Failed   int
+	Skipped  int
+	Duration time.Duration
+	Output   string // Tail of the output of failing tests, if any
+}
+
+var dayRe = regexp.MustCompile(`^(\d{4})/day(\d{2})$`)
+
+// Discover finds every `YYYY/dayNN` directory under root, sorted by year and day
+func Discover(root string) (days []Day, err error) {
+	matches, err := filepath.Glob(filepath.Join(root, "[0-9][0-9][0-9][0-9]", "day[0-9][0-9]"))
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		rel, err := filepath.Rel(root, match)
+		if err != nil {
+			return nil, err
+		}
+		parts := dayRe.FindStringSubmatch(filepath.ToSlash(rel))
+		if parts == nil {
+			continue
+		}
+		days = append(days, Day{Year: cast.ToInt(parts[1]), Day: cast.ToInt(parts[2]), Dir: rel})
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Year != days[j].Year {
+			return days[i].Year < days[j].Year
+		}
+		return days[i].Day < days[j].Day
+	})
+	return days, nil
+}
+
+// Options tweak how the tests are run
+type Options struct {
+	Workers int           // Number of days tested concurrently
+	Timeout time.Duration // Maximum time spent on a single day, 0 means no limit
+}
+
+// Run tests every given day using a pool of workers. The results are returned in the same
+// order as the days.
+func Run(ctx context.Context, root string, days []Day, opts Options) []Result {
+	workers := max(opts.Workers, 1)
+	results := make([]Result, len(days))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runDay(ctx, root, days[i], opts.Timeout)
+			}
+		}()
+	}
+	for i := range days {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func runDay(ctx context.Context, root string, day Day, timeout time.Duration) Result {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	// Include the day's sub-packages (e.g. `day07/ops`) as they may hold tests on their own
+	pkg := "./" + filepath.ToSlash(day.Dir) + "/..."
+	cmd := exec.CommandContext(ctx, "go", "test", "-json", "-count=1", pkg)
+	cmd.Dir = root
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return Result{Day: day, Status: FAIL, Output: err.Error()}
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return Result{Day: day, Status: FAIL, Output: err.Error()}
+	}
+	res := tally(stdout)
+	err = cmd.Wait()
+	res.Day = day
+	res.Duration = time.Since(start)
+	if ctx.Err() == context.DeadlineExceeded {
+		res.Status = FAIL
+		res.Output = fmt.Sprintf("timed out after %s\n%s", timeout, res.Output)
+	} else if err != nil {
+		res.Status = FAIL
+		res.Output += stderr.String()
+	}
+	return res
+}
+
+// event is the subset of the `go test -json` event we care about
+type event struct {
+	Action  string
+	Package string
+	Test    string
+	Output  string
+}
+
+// Maximum number of output lines kept per failing day
+const maxOutputLines = 20
+
+// tally reads a `go test -json` stream and counts the results of the tests. Only the leaf tests
+// (e.g. `Test_part1/actual`) are counted, as their parents just aggregate them.
+func tally(r io.Reader) (res Result) {
+	outcomes := map[string]string{}
+	var order []string
+	output := map[string][]string{}
+	packages := 0
+	skippedPackages := 0
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var ev event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		key := ev.Package + " " + ev.Test
+		switch ev.Action {
+		case "output":
+			lines := append(output[key], ev.Output)
+			if len(lines) > maxOutputLines {
+				lines = lines[len(lines)-maxOutputLines:]
+			}
+			output[key] = lines
+		case "pass", "fail", "skip":
+			if ev.Test == "" {
+				packages++
+				if ev.Action == "skip" {
+					skippedPackages++
+				}
+				if ev.Action == "fail" {
+					res.Status = FAIL
+				}
+			}
+			if _, ok := outcomes[key]; !ok {
+				order = append(order, key)
+			}
+			outcomes[key] = ev.Action
+		}
+	}
+
+	var failing strings.Builder
+	for _, key := range order {
+		pkg, test, _ := strings.Cut(key, " ")
+		if test == "" {
+			if outcomes[key] == "fail" && !hasFailedTest(outcomes, pkg) {
+				// Build failures and panics outside of a test are reported at package level
+				failing.WriteString(strings.Join(output[key], ""))
+			}
+			continue
+		}
+		if isParent(outcomes, key) {
+			continue
+		}
+		switch outcomes[key] {
+		case "pass":
+			res.Passed++
+		case "fail":
+			res.Failed++
+			failing.WriteString(strings.Join(output[key], ""))
+		case "skip":
+			res.Skipped++
+		}
+	}
+	res.Output = failing.String()
+
+	switch {
+	case res.Status == FAIL || res.Failed > 0:
+		res.Status = FAIL
+	case res.Passed == 0 && (res.Skipped > 0 || skippedPackages == packages):
+		res.Status = SKIP
+	default:
+		res.Status = PASS
+	}
+	return
+}
+
+func isParent(outcomes map[string]string, key string) bool {
+	for other := range outcomes {
+		if strings.HasPrefix(other, key+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFailedTest(outcomes map[string]string, pkg string) bool {
+	for key, outcome := range outcomes {
+		if outcome == "fail" && strings.HasPrefix(key, pkg+" ") && !strings.HasSuffix(key, " ") {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes the results as a table, followed by the output of the failing days
+func Print(w io.Writer, results []Result) {
+	var total time.Duration
+	counts := map[Status]int{}
+	fmt.Fprintf(w, "%-10s  %-6s  %7s  %7s  %7s  %10s\n", "DAY", "STATUS", "PASSED", "FAILED", "SKIPPED", "DURATION")
+	for _, r := range results {
+		counts[r.Status]++
+		total += r.Duration
+		fmt.Fprintf(
+			w, "%-10s  %-6s  %7d  %7d  %7d  %10s\n",
+			r.Day, r.Status, r.Passed, r.Failed, r.Skipped, r.Duration.Round(time.Millisecond),
+		)
+	}
+	fmt.Fprintf(
+		w, "\n%d passed, %d failed, %d skipped (%s of test time)\n",
+		counts[PASS], counts[FAIL], counts[SKIP], total.Round(time.Millisecond),
+	)
+	for _, r := range results {
+		if r.Status != FAIL || r.Output == "" {
+			continue
+		}
+		fmt.Fprintf(w, "\n--- %s\n%s", r.Day, strings.TrimRight(r.Output, "\n")+"\n")
+	}
+}
+
+// Failed reports whether any of the results is a failure
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == FAIL {
+			return true
+		}
+	}
+	return false
+}
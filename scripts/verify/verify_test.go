@@ -0,0 +1,107 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"2024/day10", "2024/day02", "2023/day25", "2024/notes", "scripts/day01"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	days, err := Discover(root)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	want := []string{"2023/day25", "2024/day02", "2024/day10"}
+	if len(days) != len(want) {
+		t.Fatalf("Discover() = %v, want %v", days, want)
+	}
+	for i, d := range days {
+		if d.String() != want[i] || filepath.ToSlash(d.Dir) != want[i] {
+			t.Errorf("Discover()[%d] = %v (%s), want %v", i, d, d.Dir, want[i])
+		}
+	}
+}
+
+func Test_tally(t *testing.T) {
+	const pkg = `"Package":"github.com/Javinator9889/aoc-2024/2024/day01"`
+	tests := []struct {
+		name    string
+		events  []string
+		want    Status
+		passed  int
+		failed  int
+		skipped int
+	}{
+		{
+			name: "pass",
+			events: []string{
+				`{"Action":"run",` + pkg + `,"Test":"Test_part1"}`,
+				`{"Action":"pass",` + pkg + `,"Test":"Test_part1/example"}`,
+				`{"Action":"pass",` + pkg + `,"Test":"Test_part1/actual"}`,
+				`{"Action":"pass",` + pkg + `,"Test":"Test_part1"}`,
+				`{"Action":"pass",` + pkg + `}`,
+			},
+			want:   PASS,
+			passed: 2,
+		},
+		{
+			name: "fail",
+			events: []string{
+				`{"Action":"pass",` + pkg + `,"Test":"Test_part1/example"}`,
+				`{"Action":"output",` + pkg + `,"Test":"Test_part1/actual","Output":"part1() = 1, want 2\n"}`,
+				`{"Action":"fail",` + pkg + `,"Test":"Test_part1/actual"}`,
+				`{"Action":"fail",` + pkg + `,"Test":"Test_part1"}`,
+				`{"Action":"fail",` + pkg + `}`,
+			},
+			want:   FAIL,
+			passed: 1,
+			failed: 1,
+		},
+		{
+			name: "skip",
+			events: []string{
+				`{"Action":"skip",` + pkg + `,"Test":"Test_part1/actual"}`,
+				`{"Action":"pass",` + pkg + `,"Test":"Test_part1"}`,
+				`{"Action":"pass",` + pkg + `}`,
+			},
+			want:    SKIP,
+			skipped: 1,
+		},
+		{
+			name: "no test files",
+			events: []string{
+				`{"Action":"skip",` + pkg + `}`,
+			},
+			want: SKIP,
+		},
+		{
+			name: "build failed",
+			events: []string{
+				`{"Action":"output",` + pkg + `,"Output":"main.go:1: syntax error\n"}`,
+				`{"Action":"fail",` + pkg + `}`,
+			},
+			want: FAIL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tally(strings.NewReader(strings.Join(tt.events, "\n")))
+			if got.Status != tt.want {
+				t.Errorf("tally().Status = %v, want %v", got.Status, tt.want)
+			}
+			if got.Passed != tt.passed || got.Failed != tt.failed || got.Skipped != tt.skipped {
+				t.Errorf(
+					"tally() = %d/%d/%d, want %d/%d/%d",
+					got.Passed, got.Failed, got.Skipped, tt.passed, tt.failed, tt.skipped,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Package store keeps puzzle inputs and prompts encrypted at rest, so the repository can be
+// shared publicly while teammates and CI holding the key can still use them.
+//
+// Files are encrypted with AES-256-GCM next to their plaintext path with an extra `.enc`
+// extension (e.g. `input.txt.enc`). Reads transparently fall back to the encrypted file when the
+// plaintext one is missing, and writes encrypt whenever a key is available.
+package store
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// KeyEnv is the env var holding the key: 32 random bytes, hex-encoded (see NewKey). Passphrases
+// are not accepted, as the encrypted files are meant to be published and would be open to
+// offline guessing.
+const KeyEnv = "AOC_INPUTS_KEY"
+
+// Ext is the extension appended to the encrypted files
+const Ext = ".enc"
+
+// Every encrypted file starts with this header, so we can detect corrupted or foreign files
+var magic = []byte("AOCENC1\n")
+
+var ErrNoKey = fmt.Errorf("no encryption key set on env var (%s)", KeyEnv)
+var ErrInvalidKey = fmt.Errorf("%s must be 32 random bytes hex-encoded, make one with `aoc store key`", KeyEnv)
+var ErrNotEncrypted = errors.New("not an encrypted file")
+
+// Key returns the key from the env var, ErrNoKey if it is not set or ErrInvalidKey if it isn't
+// a hex-encoded 32 bytes key
+func Key() ([]byte, error) {
+	secret := os.Getenv(KeyEnv)
+	if secret == "" {
+		return nil, ErrNoKey
+	}
+	key, err := hex.DecodeString(secret)
+	if err != nil || len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// NewKey returns a new random key, hex-encoded as KeyEnv expects it
+func NewKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt seals the plaintext with the given key. The output holds the header, the random nonce
+// and the ciphertext.
+func Encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	out := append(bytes.Clone(magic), nonce...)
+	return gcm.Seal(out, nonce, plaintext, magic), nil
+}
+
+// Decrypt opens a ciphertext produced by Encrypt
+func Decrypt(key, ciphertext []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, magic) {
+		return nil, ErrNotEncrypted
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext = ciphertext[len(magic):]
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrNotEncrypted
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, magic)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting (wrong %s?): %w", KeyEnv, err)
+	}
+	return plaintext, nil
+}
+
+// ReadFile reads the plaintext file if it exists, otherwise it decrypts its `.enc` counterpart.
+// If neither exist, the returned error satisfies os.IsNotExist.
+func ReadFile(filename string) ([]byte, error) {
+	content, err := os.ReadFile(filename)
+	if !os.IsNotExist(err) {
+		return content, err
+	}
+	ciphertext, encErr := os.ReadFile(filename + Ext)
+	if os.IsNotExist(encErr) {
+		// Report the plaintext file as missing, which is the one the caller asked for
+		return nil, err
+	}
+	if encErr != nil {
+		return nil, encErr
+	}
+	key, err := Key()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filename+Ext, err)
+	}
+	return Decrypt(key, ciphertext)
+}
+
+// WriteFile writes the contents encrypted into `filename.enc` when a key is available, removing
+// any stale plaintext copy, or as plaintext into filename otherwise. The written path is returned.
+func WriteFile(filename string, contents []byte, perm os.FileMode) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+		return "", err
+	}
+	key, err := Key()
+	if errors.Is(err, ErrNoKey) {
+		return filename, os.WriteFile(filename, contents, perm)
+	}
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := Encrypt(key, contents)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filename+Ext, ciphertext, perm); err != nil {
+		return "", err
+	}
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	return filename + Ext, nil
+}
+
+// Seal encrypts the plaintext file into `filename.enc` and removes the plaintext
+func Seal(filename string) error {
+	key, err := Key()
+	if err != nil {
+		return err
+	}
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := Encrypt(key, contents)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename+Ext, ciphertext, 0644); err != nil {
+		return err
+	}
+	return os.Remove(filename)
+}
+
+// Unseal decrypts `filename.enc` back into the plaintext filename. The encrypted file is kept.
+func Unseal(filename string) error {
+	key, err := Key()
+	if err != nil {
+		return err
+	}
+	ciphertext, err := os.ReadFile(filename + Ext)
+	if err != nil {
+		return err
+	}
+	contents, err := Decrypt(key, ciphertext)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, contents, 0644)
+}
@@ -0,0 +1,135 @@
+package store_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Javinator9889/aoc-2024/store"
+)
+
+func TestKey(t *testing.T) {
+	valid := newKey(t)
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr error
+	}{
+		{"valid", valid, nil},
+		{"unset", "", store.ErrNoKey},
+		{"passphrase", "correct horse battery staple", store.ErrInvalidKey},
+		{"short", valid[:32], store.ErrInvalidKey},
+		{"not hex", strings.Repeat("z", 64), store.ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(store.KeyEnv, tt.secret)
+			key, err := store.Key()
+			if err != tt.wantErr {
+				t.Fatalf("Key() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && hex.EncodeToString(key) != tt.secret {
+				t.Errorf("Key() = %x, want %s", key, tt.secret)
+			}
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Setenv(store.KeyEnv, newKey(t))
+	key, err := store.Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := []byte("3   4\n4   3\n2   5\n")
+	ciphertext, err := store.Encrypt(key, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Errorf("Encrypt() leaks the plaintext")
+	}
+	got, err := store.Decrypt(key, ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("Decrypt() = %q, want %q", got, plaintext)
+	}
+
+	t.Setenv(store.KeyEnv, newKey(t))
+	wrong, _ := store.Key()
+	if _, err := store.Decrypt(wrong, ciphertext); err == nil {
+		t.Errorf("Decrypt() with the wrong key should fail")
+	}
+	if _, err := store.Decrypt(key, plaintext); err != store.ErrNotEncrypted {
+		t.Errorf("Decrypt() of a plaintext = %v, want %v", err, store.ErrNotEncrypted)
+	}
+}
+
+func TestReadWriteFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "2024", "day01", "input.txt")
+	if _, err := store.ReadFile(filename); !os.IsNotExist(err) {
+		t.Errorf("ReadFile() of a missing file error = %v, want not exist", err)
+	}
+
+	t.Setenv(store.KeyEnv, "")
+	written, err := store.WriteFile(filename, []byte("plain"), 0644)
+	if err != nil || written != filename {
+		t.Fatalf("WriteFile() without key = %v, %v, want %v", written, err, filename)
+	}
+	if err := os.Remove(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(store.KeyEnv, "correct horse battery staple")
+	if _, err := store.WriteFile(filename, []byte("sealed"), 0644); err != store.ErrInvalidKey {
+		t.Errorf("WriteFile() with a passphrase error = %v, want %v", err, store.ErrInvalidKey)
+	}
+
+	t.Setenv(store.KeyEnv, newKey(t))
+	written, err = store.WriteFile(filename, []byte("sealed"), 0644)
+	if err != nil || written != filename+store.Ext {
+		t.Fatalf("WriteFile() with key = %v, %v, want %v", written, err, filename+store.Ext)
+	}
+	if got, err := store.ReadFile(filename); err != nil || string(got) != "sealed" {
+		t.Errorf("ReadFile() = %q, %v, want %q", got, err, "sealed")
+	}
+
+	t.Setenv(store.KeyEnv, "")
+	if _, err := store.ReadFile(filename); err == nil {
+		t.Errorf("ReadFile() of an encrypted file without key should fail")
+	}
+}
+
+func TestSealUnseal(t *testing.T) {
+	t.Setenv(store.KeyEnv, newKey(t))
+	filename := filepath.Join(t.TempDir(), "prompt.md")
+	if err := os.WriteFile(filename, []byte("--- Day 1 ---"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Seal(filename); err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if _, err := os.Stat(filename); !os.IsNotExist(err) {
+		t.Errorf("Seal() should remove the plaintext file")
+	}
+	if err := store.Unseal(filename); err != nil {
+		t.Fatalf("Unseal() error = %v", err)
+	}
+	if got, _ := os.ReadFile(filename); string(got) != "--- Day 1 ---" {
+		t.Errorf("Unseal() wrote %q", got)
+	}
+}
+
+func newKey(t *testing.T) string {
+	t.Helper()
+	key, err := store.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
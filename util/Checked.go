@@ -0,0 +1,37 @@
+package util
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrOverflow is returned by the checked operations when the result doesn't fit in an int
+var ErrOverflow = errors.New("integer overflow")
+
+// CheckedAdd returns a + b, failing instead of overflowing
+func CheckedAdd(a, b int) (int, error) {
+	if (b > 0 && a > math.MaxInt-b) || (b < 0 && a < math.MinInt-b) {
+		return 0, ErrOverflow
+	}
+	return a + b, nil
+}
+
+// CheckedSub returns a - b, failing instead of overflowing
+func CheckedSub(a, b int) (int, error) {
+	if (b < 0 && a > math.MaxInt+b) || (b > 0 && a < math.MinInt+b) {
+		return 0, ErrOverflow
+	}
+	return a - b, nil
+}
+
+// CheckedMul returns a * b, failing instead of overflowing
+func CheckedMul(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	r := a * b
+	if r/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, ErrOverflow
+	}
+	return r, nil
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(a, b int) (int, error)
+		a, b    int
+		want    int
+		wantErr error
+	}{
+		{"add", CheckedAdd, 40, 2, 42, nil},
+		{"add negative", CheckedAdd, math.MinInt + 1, -1, math.MinInt, nil},
+		{"add overflow", CheckedAdd, math.MaxInt, 1, 0, ErrOverflow},
+		{"add underflow", CheckedAdd, math.MinInt, -1, 0, ErrOverflow},
+		{"sub", CheckedSub, 44, 2, 42, nil},
+		{"sub overflow", CheckedSub, math.MaxInt, -1, 0, ErrOverflow},
+		{"sub underflow", CheckedSub, math.MinInt, 1, 0, ErrOverflow},
+		{"sub min", CheckedSub, 0, math.MinInt, 0, ErrOverflow},
+		{"mul", CheckedMul, 6, 7, 42, nil},
+		{"mul zero", CheckedMul, math.MaxInt, 0, 0, nil},
+		{"mul min", CheckedMul, math.MinInt / 2, 2, math.MinInt, nil},
+		{"mul overflow", CheckedMul, math.MaxInt/2 + 1, 2, 0, ErrOverflow},
+		{"mul negated min", CheckedMul, -1, math.MinInt, 0, ErrOverflow},
+		{"mul min negated", CheckedMul, math.MinInt, -1, 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op(tt.a, tt.b)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("%s(%d, %d) = %v, %v, want %v, %v", tt.name, tt.a, tt.b, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,11 +1,17 @@
 package util
 
-import "testing"
+import (
+	"os/exec"
+	"testing"
+)
 
 // not a real test... used o run copy to clipboard without a main package
 func TestCopyToClipboard(t *testing.T) {
+	if _, err := exec.LookPath("pbcopy"); err != nil {
+		t.Skip("pbcopy is not available (macOS only)")
+	}
 	err := CopyToClipboard("asdfqwert")
 	if err != nil {
-		t.Errorf("Unexpected error while running CopyToClipboard: %w", err)
+		t.Errorf("Unexpected error while running CopyToClipboard: %v", err)
 	}
 }
@@ -0,0 +1,115 @@
+package util
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Javinator9889/aoc-2024/store"
+)
+
+// InputsDirEnv is the env var pointing to a directory holding the puzzle inputs, laid out as
+// `YYYY/dayNN/input.txt`. When unset, the inputs are read from each day's own directory.
+const InputsDirEnv = "AOC_INPUTS_DIR"
+
+// Input reads the puzzle input of the calling day at runtime, looking into $AOC_INPUTS_DIR
+// first (if set). The day is determined from the caller's source file location.
+//
+// Inputs are not meant to be committed, so an empty string is returned if the file is missing
+// or can't be read (e.g. only its encrypted copy exists and $AOC_INPUTS_KEY is not set or wrong)
+// instead of failing: the examples can still be tested on a fresh clone.
+func Input() string {
+	return readInput(InputPath(callerDir(), os.Getenv(InputsDirEnv)))
+}
+
+// InputIn is like Input but reads the input from the given inputs directory
+func InputIn(inputsDir string) string {
+	return readInput(InputPath(callerDir(), inputsDir))
+}
+
+// InputFlags registers the -input and -inputs flags on fs, for the calling day. The returned
+// function reads the input once fs is parsed: from the -input file (or stdin), from the -inputs
+// directory, or as Input does. It exits if the input is empty or missing, as there is nothing
+// to solve.
+func InputFlags(fs *flag.FlagSet) func() string {
+	dayDir := callerDir()
+	inputFile := fs.String("input", "", "file with the puzzle input, \"-\" reads it from stdin")
+	inputs := fs.String("inputs", "", "directory with the puzzle inputs (default $AOC_INPUTS_DIR)")
+	return func() string {
+		var input string
+		switch {
+		case *inputFile != "":
+			var err error
+			if input, err = ReadInput(*inputFile); err != nil {
+				log.Fatalf("reading input: %s", err)
+			}
+		case *inputs != "":
+			input = readInput(InputPath(dayDir, *inputs))
+		default:
+			input = readInput(InputPath(dayDir, os.Getenv(InputsDirEnv)))
+		}
+		if len(input) == 0 {
+			log.Fatal("empty or missing input.txt file, fetch it with `make input`")
+		}
+		return input
+	}
+}
+
+// InputPath returns the path to the input of the day whose sources live in dayDir. If inputsDir
+// is empty, the input is expected to be next to the sources.
+func InputPath(dayDir, inputsDir string) string {
+	return dataPath(dayDir, inputsDir, InputName(""))
+}
+
+// dataPath returns the path to a per-day data file (inputs, answers...) with the given name
+func dataPath(dayDir, inputsDir, name string) string {
+	if inputsDir == "" {
+		return filepath.Join(dayDir, name)
+	}
+	year, day := filepath.Base(filepath.Dir(dayDir)), filepath.Base(dayDir)
+	return filepath.Join(inputsDir, year, day, name)
+}
+
+// callerDir returns the directory of the function calling the exported function in this file
+func callerDir() string {
+	_, filename, _, ok := runtime.Caller(2)
+	if !ok {
+		panic("Could not find Caller of util.Input")
+	}
+	return filepath.Dir(filename)
+}
+
+func readInput(filename string) string {
+	content, err := store.ReadFile(filename)
+	if os.IsNotExist(err) || errors.Is(err, store.ErrNoKey) {
+		return ""
+	}
+	if err != nil {
+		// e.g. a wrong key, which shouldn't keep the examples from being tested
+		log.Printf("ignoring the input %s: %s", filename, err)
+		return ""
+	}
+	// trim off new lines at end of input files
+	return strings.TrimRight(string(content), "\n")
+}
+
+// ReadInput reads a puzzle input from the given file, or from stdin if filename is "-". Encrypted
+// files are transparently decrypted, see the store package.
+func ReadInput(filename string) (string, error) {
+	var content []byte
+	var err error
+	if filename == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = store.ReadFile(filename)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\n"), nil
+}
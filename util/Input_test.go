@@ -0,0 +1,138 @@
+package util
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Javinator9889/aoc-2024/store"
+)
+
+func TestInputPath(t *testing.T) {
+	dayDir := filepath.Join("repo", "2024", "day06")
+	tests := []struct {
+		name      string
+		inputsDir string
+		want      string
+	}{
+		{"default", "", filepath.Join("repo", "2024", "day06", "input.txt")},
+		{"inputs dir", "inputs", filepath.Join("inputs", "2024", "day06", "input.txt")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InputPath(dayDir, tt.inputsDir); got != tt.want {
+				t.Errorf("InputPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readInput(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "input.txt")
+	if got := readInput(filename); got != "" {
+		t.Errorf("readInput() of a missing file = %q, want empty", got)
+	}
+	if err := os.WriteFile(filename, []byte("1 2\n3 4\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readInput(filename); got != "1 2\n3 4" {
+		t.Errorf("readInput() = %q, want %q", got, "1 2\n3 4")
+	}
+
+	encrypted := filepath.Join(dir, "encrypted.txt")
+	key, err := store.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong, err := store.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(store.KeyEnv, key)
+	if _, err := store.WriteFile(encrypted, []byte("5 6\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readInput(encrypted); got != "5 6" {
+		t.Errorf("readInput() of an encrypted file = %q, want %q", got, "5 6")
+	}
+	t.Setenv(store.KeyEnv, wrong)
+	if got := readInput(encrypted); got != "" {
+		t.Errorf("readInput() with a wrong key = %q, want empty", got)
+	}
+	t.Setenv(store.KeyEnv, "")
+	if got := readInput(encrypted); got != "" {
+		t.Errorf("readInput() without a key = %q, want empty", got)
+	}
+}
+
+func TestInputFlags(t *testing.T) {
+	// The inputs are looked for as if this package was a day
+	_, filename, _, _ := runtime.Caller(0)
+	inputs := t.TempDir()
+	inputFile := filepath.Join(t.TempDir(), "input.txt")
+	for name, content := range map[string]string{
+		InputPath(filepath.Dir(filename), inputs): "from inputs\n",
+		inputFile: "from file\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name string
+		args []string
+		env  string
+		want string
+	}{
+		{"input", []string{"-input", inputFile}, "", "from file"},
+		{"inputs", []string{"-inputs", inputs}, "", "from inputs"},
+		{"inputs env", nil, inputs, "from inputs"},
+		{"input over inputs", []string{"-input", inputFile, "-inputs", inputs}, "", "from file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(InputsDirEnv, tt.env)
+			fs := flag.NewFlagSet("day", flag.ContinueOnError)
+			read := InputFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := read(); got != tt.want {
+				t.Errorf("InputFlags() read %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	dayDir := filepath.Join(t.TempDir(), "2024", "day01")
+	if err := os.MkdirAll(dayDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"input.txt", "input.bob.txt", "input.alice.txt.enc", "input.bob.txt.enc", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dayDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := Profiles(dayDir, "")
+	if len(got) != 2 || got[0] != "alice" || got[1] != "bob" {
+		t.Errorf("Profiles() = %v, want [alice bob]", got)
+	}
+
+	if _, err := WriteAnswer(dayDir, "", "bob", 2, "31"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteAnswer(dayDir, "", "bob", 1, "11"); err != nil {
+		t.Fatal(err)
+	}
+	answers, err := Answers(dayDir, "", "bob")
+	if err != nil || len(answers) != 2 || answers[0] != "11" || answers[1] != "31" {
+		t.Errorf("Answers() = %v, %v, want [11 31]", answers, err)
+	}
+}
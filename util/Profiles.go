@@ -0,0 +1,95 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Javinator9889/aoc-2024/store"
+)
+
+// Each team account (profile) gets its own input, stored as `input.<profile>.txt`, and the answers
+// accepted for it, stored as `answers.<profile>.txt` with one line per part. The default profile
+// is the empty one, whose input is the regular `input.txt`.
+
+// InputName returns the name of the input file of the given profile
+func InputName(profile string) string {
+	if profile == "" {
+		return "input.txt"
+	}
+	return "input." + profile + ".txt"
+}
+
+// AnswersName returns the name of the file with the recorded answers of the given profile
+func AnswersName(profile string) string {
+	if profile == "" {
+		return "answers.txt"
+	}
+	return "answers." + profile + ".txt"
+}
+
+// ProfileInputPath is like InputPath for the input of the given profile
+func ProfileInputPath(dayDir, inputsDir, profile string) string {
+	return dataPath(dayDir, inputsDir, InputName(profile))
+}
+
+// AnswersPath returns the path to the recorded answers of the given profile
+func AnswersPath(dayDir, inputsDir, profile string) string {
+	return dataPath(dayDir, inputsDir, AnswersName(profile))
+}
+
+// Profiles lists the named profiles having an input for the day whose sources live in dayDir,
+// either as plaintext or encrypted.
+func Profiles(dayDir, inputsDir string) (profiles []string) {
+	seen := map[string]bool{}
+	for _, pattern := range []string{"input.*.txt", "input.*.txt" + store.Ext} {
+		matches, _ := filepath.Glob(dataPath(dayDir, inputsDir, pattern))
+		for _, match := range matches {
+			name := strings.TrimSuffix(filepath.Base(match), store.Ext)
+			profile := strings.TrimSuffix(strings.TrimPrefix(name, "input."), ".txt")
+			if profile != "" && !seen[profile] {
+				seen[profile] = true
+				profiles = append(profiles, profile)
+			}
+		}
+	}
+	sort.Strings(profiles)
+	return
+}
+
+// ProfileInput reads the input of the given profile, returning an empty string if it's missing
+func ProfileInput(dayDir, inputsDir, profile string) string {
+	return readInput(ProfileInputPath(dayDir, inputsDir, profile))
+}
+
+// Answers reads the answers recorded for the given profile, indexed by part - 1. Parts without a
+// known answer are empty strings.
+func Answers(dayDir, inputsDir, profile string) ([]string, error) {
+	content, err := store.ReadFile(AnswersPath(dayDir, inputsDir, profile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	answers := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	for i := range answers {
+		answers[i] = strings.TrimSpace(answers[i])
+	}
+	return answers, nil
+}
+
+// WriteAnswer records the answer of a profile for the given part, keeping the other parts
+func WriteAnswer(dayDir, inputsDir, profile string, part int, answer string) (string, error) {
+	answers, err := Answers(dayDir, inputsDir, profile)
+	if err != nil {
+		return "", err
+	}
+	for len(answers) < part {
+		answers = append(answers, "")
+	}
+	answers[part-1] = answer
+	content := strings.Join(answers, "\n") + "\n"
+	return store.WriteFile(AnswersPath(dayDir, inputsDir, profile), []byte(content), 0644)
+}
@@ -11,7 +11,7 @@ import (
 // ReadFile is a wrapper over io/ioutil.ReadFile but also determines the dynamic
 // absolute path to the file.
 //
-// Deprecated in favor of go:embed, refer to scripts/skeleton/tmpls
+// Deprecated in favor of Input, refer to scripts/skeleton/tmpls
 func ReadFile(pathFromCaller string) string {
 	// Docs: https://golang.org/pkg/runtime/#Caller
 	_, filename, _, ok := runtime.Caller(1)
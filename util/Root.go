@@ -0,0 +1,63 @@
+package util
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// modulePath is the path of this module, e.g. "github.com/Javinator9889/aoc-2024"
+var modulePath = strings.TrimSuffix(reflect.TypeOf(marker{}).PkgPath(), "/util")
+
+type marker struct{}
+
+// Root returns the root directory of the module, where the `YYYY/dayNN` directories live. It is
+// looked up from the working directory first, so binaries built elsewhere still work when run
+// from within the repository, and then from the location of the sources.
+func Root() string {
+	if wd, err := os.Getwd(); err == nil {
+		if root, ok := findModule(wd, modulePath); ok {
+			return root
+		}
+	}
+	_, filename, _, ok := runtime.Caller(0)
+	if ok {
+		if root, ok := findModule(filepath.Dir(filename), modulePath); ok {
+			return root
+		}
+	}
+	panic("could not find the root of module " + modulePath)
+}
+
+// findModule walks up from dir looking for the go.mod declaring the given module
+func findModule(dir, module string) (string, bool) {
+	for {
+		if moduleOf(filepath.Join(dir, "go.mod")) == module {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// moduleOf returns the module declared by the go.mod file, if any
+func moduleOf(gomod string) string {
+	f, err := os.Open(gomod)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`)
+		}
+	}
+	return ""
+}
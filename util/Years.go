@@ -0,0 +1,84 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FirstYear is the year of the first Advent of Code
+const FirstYear = 2015
+
+// DaysIn returns the number of puzzles of the given year: 25, down to 12 from 2025 onward
+func DaysIn(year int) int {
+	if year >= 2025 {
+		return 12
+	}
+	return 25
+}
+
+// CheckDay fails if the day doesn't have a puzzle, or the year an event
+func CheckDay(year, day int) error {
+	if err := CheckYear(year); err != nil {
+		return err
+	}
+	if day < 1 || day > DaysIn(year) {
+		return fmt.Errorf("day out of range: %d, %d has days 1 through %d", day, year, DaysIn(year))
+	}
+	return nil
+}
+
+// CheckYear fails if the year doesn't have an event, whether it already happened or not
+func CheckYear(year int) error {
+	if year < FirstYear {
+		return fmt.Errorf("year is before %d: %d", FirstYear, year)
+	}
+	if now := time.Now().Year(); year > now {
+		return fmt.Errorf("year is in the future: %d", year)
+	}
+	return nil
+}
+
+// LatestYear returns the year of the last event as of now: the current year in December, and
+// the previous one until then
+func LatestYear(now time.Time) int {
+	if now.Month() == time.December {
+		return now.Year()
+	}
+	return now.Year() - 1
+}
+
+// Today returns the day of the puzzle released today, or 0 if there is none. Puzzles are
+// released at midnight EST (UTC-5).
+func Today(now time.Time) int {
+	est := now.In(time.FixedZone("EST", -5*60*60))
+	if est.Month() != time.December || est.Day() > DaysIn(est.Year()) {
+		return 0
+	}
+	return est.Day()
+}
+
+// Years returns the `YYYY` directories found under root, sorted
+func Years(root string) ([]int, error) {
+	matches, err := filepath.Glob(filepath.Join(root, "[0-9][0-9][0-9][0-9]"))
+	if err != nil {
+		return nil, err
+	}
+	var years []int
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		year, err := strconv.Atoi(filepath.Base(match))
+		if err != nil || year < FirstYear {
+			continue
+		}
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years, nil
+}
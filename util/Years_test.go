@@ -0,0 +1,87 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCheckDay(t *testing.T) {
+	tests := []struct {
+		year, day int
+		wantErr   bool
+	}{
+		{2015, 1, false},
+		{2024, 25, false},
+		{2024, 26, true},
+		{2025, 12, false},
+		{2025, 13, true},
+		{2024, 0, true},
+		{2014, 1, true},
+		{time.Now().Year() + 1, 1, true},
+	}
+	for _, tt := range tests {
+		if err := CheckDay(tt.year, tt.day); (err != nil) != tt.wantErr {
+			t.Errorf("CheckDay(%d, %d) error = %v, wantErr %v", tt.year, tt.day, err, tt.wantErr)
+		}
+	}
+}
+
+func TestToday(t *testing.T) {
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantDay    int
+		wantLatest int
+	}{
+		{"first puzzle", time.Date(2024, time.December, 1, 5, 0, 0, 0, time.UTC), 1, 2024},
+		{"before midnight EST", time.Date(2024, time.December, 1, 4, 59, 0, 0, time.UTC), 0, 2024},
+		{"after the 12 days", time.Date(2025, time.December, 13, 12, 0, 0, 0, time.UTC), 0, 2025},
+		{"january", time.Date(2025, time.January, 2, 12, 0, 0, 0, time.UTC), 0, 2024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Today(tt.now); got != tt.wantDay {
+				t.Errorf("Today() = %d, want %d", got, tt.wantDay)
+			}
+			if got := LatestYear(tt.now); got != tt.wantLatest {
+				t.Errorf("LatestYear() = %d, want %d", got, tt.wantLatest)
+			}
+		})
+	}
+}
+
+func TestYears(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"2023/day01", "2015", "2024/day01", "1999", "util"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := Years(root)
+	if err != nil {
+		t.Fatalf("Years() error = %v", err)
+	}
+	if want := []int{2015, 2023, 2024}; !slices.Equal(got, want) {
+		t.Errorf("Years() = %v, want %v", got, want)
+	}
+}
+
+func Test_findModule(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "2024", "day01")
+	if err := os.MkdirAll(nested, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/aoc\n\ngo 1.23\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := findModule(nested, "example.com/aoc"); !ok || got != root {
+		t.Errorf("findModule() = %q, %v, want %q", got, ok, root)
+	}
+	if _, ok := findModule(nested, "example.com/other"); ok {
+		t.Error("findModule() found a go.mod of another module")
+	}
+}